@@ -4,7 +4,9 @@ go 1.25.4
 
 require (
 	github.com/go-resty/resty/v2 v2.17.1
+	github.com/skip2/go-qrcode v0.0.0-20200617195104-da1b6568686e
 	go.uber.org/zap v1.27.1
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
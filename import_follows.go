@@ -0,0 +1,67 @@
+// Package main 提供 import-follows 子命令
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/service"
+)
+
+// runImportFollows 执行 import-follows 子命令，返回进程退出码
+// 用法: glance-bilibili import-follows -mid <mid> [-config path] [-dry-run]
+func runImportFollows(args []string) int {
+	fs := flag.NewFlagSet("import-follows", flag.ExitOnError)
+	configPath := fs.String("config", "", "配置文件路径")
+	mid := fs.String("mid", "", "关注列表公开的用户 UID")
+	dryRun := fs.Bool("dry-run", false, "仅显示变更，不写入配置文件")
+	fs.Parse(args)
+
+	if *mid == "" {
+		fmt.Fprintln(os.Stderr, "缺少 -mid 参数")
+		fs.Usage()
+		return 2
+	}
+
+	config.SetMidResolver(service.NewMidResolver())
+	cfg, err := config.Load(*configPath)
+	if err != nil {
+		logger.Errorw("加载配置失败", "error", err)
+		return 1
+	}
+
+	settings, err := cfg.Settings()
+	if err != nil {
+		logger.Errorw("读取全局设置失败", "error", err)
+		return 1
+	}
+
+	svc := service.NewVideoService(cfg, settings.Fetch)
+	credentialPath := config.CredentialsPath(*configPath)
+	if err := svc.UseCredentialFile(credentialPath); err != nil {
+		logger.Warnw("加载凭据文件失败", "path", credentialPath, "error", err)
+	}
+	if err := svc.Initialize(); err != nil {
+		logger.Warnw("初始化警告", "error", err)
+	}
+
+	diff, err := svc.ImportFollowings(*mid, *configPath, *dryRun)
+	if err != nil {
+		logger.Errorw("导入关注列表失败", "mid", *mid, "error", err)
+		return 1
+	}
+
+	fmt.Print(diff.String())
+	switch {
+	case *dryRun:
+		fmt.Println("dry-run 模式，未写入配置文件")
+	case diff.HasChanges():
+		fmt.Printf("已写入 %s\n", config.ResolvePath(*configPath))
+	default:
+		fmt.Println("没有需要写入的变更")
+	}
+	return 0
+}
@@ -0,0 +1,144 @@
+// Package api 提供管理接口处理器
+package api
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/service"
+)
+
+// EnableAdmin 启用管理接口
+// token 为空时管理接口保持关闭；configPath 为导入等操作写入的配置文件路径
+func (h *Handler) EnableAdmin(token string, configPath string) {
+	h.adminToken = token
+	h.configPath = configPath
+}
+
+// authorizeAdmin 校验管理接口令牌，失败时写入错误响应并返回 false
+// 令牌可通过 Authorization: Bearer <token> 请求头或 token 查询参数传递
+func (h *Handler) authorizeAdmin(w http.ResponseWriter, r *http.Request) bool {
+	if h.adminToken == "" {
+		writeJSONError(w, http.StatusForbidden, "管理接口未启用，请通过 -admin-token 或 ADMIN_TOKEN 设置令牌")
+		return false
+	}
+
+	token := r.URL.Query().Get("token")
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		token = strings.TrimPrefix(auth, "Bearer ")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(h.adminToken)) != 1 {
+		writeJSONError(w, http.StatusUnauthorized, "管理令牌无效")
+		return false
+	}
+	return true
+}
+
+// writeJSONError 以 JSON 格式输出错误
+func writeJSONError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
+
+// ImportFollowsHandler 从公开关注列表导入 UP 主
+// POST /admin/import-follows?mid=<mid>&dry_run=true
+func (h *Handler) ImportFollowsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持 POST")
+		return
+	}
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	query := r.URL.Query()
+	mid := query.Get("mid")
+	if mid == "" {
+		writeJSONError(w, http.StatusBadRequest, "缺少 mid 参数")
+		return
+	}
+	dryRun, _ := strconv.ParseBool(query.Get("dry_run"))
+
+	diff, err := h.service.ImportFollowings(mid, h.configPath, dryRun)
+	if err != nil {
+		logger.Errorw("导入关注列表失败",
+			"mid", mid,
+			"error", err,
+		)
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	saved := !dryRun && diff.HasChanges()
+	message := "未写入配置"
+	response := map[string]interface{}{
+		"dry_run": dryRun,
+		"saved":   saved,
+		"diff":    diff,
+	}
+	if saved {
+		// 写入后立即重新加载，无需等待配置文件监听
+		result := h.service.ReloadConfig(h.configPath, service.ReloadTriggerAdmin)
+		response["reload"] = result
+		message = "配置已写入并重新加载"
+		if !result.Success {
+			message = "配置已写入，但重新加载失败: " + result.Error
+		}
+	}
+	response["message"] = message
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// PoolHandler 返回请求身份池状态：分配策略、各身份的请求数、风控次数与隔离状态
+// GET /admin/pool?token=<token>
+func (h *Handler) PoolHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(h.service.PoolStatus())
+}
+
+// ReloadHandler 查询或触发配置重载
+// GET /admin/reload 返回最近一次重载结果，POST /admin/reload 立即重新加载配置文件
+func (h *Handler) ReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持 GET 与 POST")
+		return
+	}
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.Method == http.MethodPost {
+		result := h.service.ReloadConfig(h.configPath, service.ReloadTriggerAdmin)
+		if !result.Success {
+			// 配置文件有误，当前配置保持不变
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	result, ok := h.service.LastReload()
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "尚未重新加载过配置")
+		return
+	}
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,242 @@
+// Package api 提供频道与分组管理 REST 接口
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+	"glance-bilibili/internal/service"
+)
+
+// maxAdminBodySize 管理接口请求体大小上限
+const maxAdminBodySize = 1 << 20
+
+// channelsResponse 频道管理接口的响应
+type channelsResponse struct {
+	Channels []config.ChannelInfo `json:"channels"`
+	Channel  *config.ChannelInfo  `json:"channel,omitempty"` // 新增或修改后的频道
+	Reload   *models.ReloadResult `json:"reload,omitempty"`
+	Message  string               `json:"message,omitempty"`
+}
+
+// groupsRequest 分组管理接口的请求与响应
+type groupsRequest struct {
+	Groups  []config.GroupInfo   `json:"groups"`
+	Reload  *models.ReloadResult `json:"reload,omitempty"`
+	Message string               `json:"message,omitempty"`
+}
+
+// reorderRequest 频道排序请求
+type reorderRequest struct {
+	Order []int `json:"order"` // 新顺序，由现有下标组成
+}
+
+// ChannelsAPIHandler 列出或添加频道
+// GET /admin/api/channels 返回配置文件中的频道列表，POST /admin/api/channels 在末尾添加频道
+func (h *Handler) ChannelsAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持 GET 与 POST")
+		return
+	}
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		channels, err := h.service.ListChannels(h.configPath)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		writeChannelsResponse(w, http.StatusOK, channelsResponse{Channels: channels})
+		return
+	}
+
+	var ch config.ChannelInfo
+	if !decodeAdminBody(w, r, &ch) {
+		return
+	}
+	added, result, err := h.service.AddChannel(h.configPath, ch)
+	if err != nil {
+		writeEditError(w, "添加频道失败", err)
+		return
+	}
+	h.writeChannelsResult(w, http.StatusCreated, &added, result)
+}
+
+// ChannelAPIHandler 修改或删除单个频道
+// PUT /admin/api/channels/{index} 以请求体整体替换频道，DELETE /admin/api/channels/{index} 删除频道
+func (h *Handler) ChannelAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodDelete {
+		w.Header().Set("Allow", "PUT, DELETE")
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持 PUT 与 DELETE")
+		return
+	}
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	index, err := strconv.Atoi(r.PathValue("index"))
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "index 必须为整数")
+		return
+	}
+
+	if r.Method == http.MethodDelete {
+		result, err := h.service.DeleteChannel(h.configPath, index)
+		if err != nil {
+			writeEditError(w, "删除频道失败", err)
+			return
+		}
+		h.writeChannelsResult(w, http.StatusOK, nil, result)
+		return
+	}
+
+	var ch config.ChannelInfo
+	if !decodeAdminBody(w, r, &ch) {
+		return
+	}
+	updated, result, err := h.service.UpdateChannel(h.configPath, index, ch)
+	if err != nil {
+		writeEditError(w, "修改频道失败", err)
+		return
+	}
+	h.writeChannelsResult(w, http.StatusOK, &updated, result)
+}
+
+// ReorderChannelsHandler 调整频道顺序
+// POST /admin/api/channels/reorder，请求体 {"order": [2, 0, 1]}
+func (h *Handler) ReorderChannelsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持 POST")
+		return
+	}
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	var req reorderRequest
+	if !decodeAdminBody(w, r, &req) {
+		return
+	}
+	result, err := h.service.ReorderChannels(h.configPath, req.Order)
+	if err != nil {
+		writeEditError(w, "调整频道顺序失败", err)
+		return
+	}
+	h.writeChannelsResult(w, http.StatusOK, nil, result)
+}
+
+// GroupsAPIHandler 查询或整体替换频道分组
+// GET /admin/api/groups 返回配置文件中的分组，PUT /admin/api/groups 以请求体 {"groups": [...]} 替换全部分组
+func (h *Handler) GroupsAPIHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPut {
+		w.Header().Set("Allow", "GET, PUT")
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持 GET 与 PUT")
+		return
+	}
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if r.Method == http.MethodGet {
+		groups, err := h.service.ListGroups(h.configPath)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		json.NewEncoder(w).Encode(groupsRequest{Groups: groups})
+		return
+	}
+
+	var req groupsRequest
+	if !decodeAdminBody(w, r, &req) {
+		return
+	}
+	result, err := h.service.SetGroups(h.configPath, req.Groups)
+	if err != nil {
+		writeEditError(w, "修改分组失败", err)
+		return
+	}
+	groups, err := h.service.ListGroups(h.configPath)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	json.NewEncoder(w).Encode(groupsRequest{
+		Groups:  groups,
+		Reload:  &result,
+		Message: reloadMessage(result),
+	})
+}
+
+// decodeAdminBody 解析 JSON 请求体，拒绝未知字段，失败时写入错误响应并返回 false
+func decodeAdminBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxAdminBodySize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "请求体不是合法的 JSON: "+err.Error())
+		return false
+	}
+	return true
+}
+
+// writeEditError 根据错误类型返回对应的状态码
+func writeEditError(w http.ResponseWriter, action string, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, service.ErrChannelNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrChannelExists):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrInvalidChannel), errors.Is(err, service.ErrInvalidGroup):
+		status = http.StatusUnprocessableEntity
+	default:
+		logger.Errorw(action,
+			"error", err,
+		)
+	}
+	writeJSONError(w, status, err.Error())
+}
+
+// writeChannelsResult 输出修改后配置文件中的频道列表与重载结果
+// 与 GET 相同读取配置文件，重载失败时下标与 mid 写法仍与文件一致
+func (h *Handler) writeChannelsResult(w http.ResponseWriter, status int, ch *config.ChannelInfo, result models.ReloadResult) {
+	channels, err := h.service.ListChannels(h.configPath)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeChannelsResponse(w, status, channelsResponse{
+		Channels: channels,
+		Channel:  ch,
+		Reload:   &result,
+		Message:  reloadMessage(result),
+	})
+}
+
+// reloadMessage 返回写入配置后重新加载结果的说明
+func reloadMessage(result models.ReloadResult) string {
+	if !result.Success {
+		return "配置已写入，但重新加载失败: " + result.Error
+	}
+	return "配置已写入并重新加载"
+}
+
+// writeChannelsResponse 以 JSON 格式输出频道管理接口的响应
+func writeChannelsResponse(w http.ResponseWriter, status int, resp channelsResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(resp)
+}
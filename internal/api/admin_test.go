@@ -0,0 +1,84 @@
+// Package api 管理接口单元测试
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/service"
+)
+
+// TestAuthorizeAdmin 测试管理令牌校验
+func TestAuthorizeAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		adminToken string
+		target     string
+		header     string
+		wantOK     bool
+		wantStatus int
+	}{
+		{name: "未启用管理接口", adminToken: "", target: "/admin", wantStatus: http.StatusForbidden},
+		{name: "缺少令牌", adminToken: "secret", target: "/admin", wantStatus: http.StatusUnauthorized},
+		{name: "令牌错误", adminToken: "secret", target: "/admin?token=wrong", wantStatus: http.StatusUnauthorized},
+		{name: "查询参数令牌", adminToken: "secret", target: "/admin?token=secret", wantOK: true},
+		{name: "Bearer 令牌", adminToken: "secret", target: "/admin", header: "Bearer secret", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{adminToken: tt.adminToken}
+			r := httptest.NewRequest(http.MethodPost, tt.target, nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+			w := httptest.NewRecorder()
+
+			ok := h.authorizeAdmin(w, r)
+			if ok != tt.wantOK {
+				t.Fatalf("authorizeAdmin() = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok && w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+// TestChannelAPIHandler_Response 测试修改频道后的响应与 GET 一致，返回配置文件中的 mid 写法
+func TestChannelAPIHandler_Response(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "channels:\n  - mid: https://space.bilibili.com/946974\n  - type: popular\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := service.NewVideoService(cfg, config.FetchConfig{Workers: 1})
+	defer svc.Shutdown()
+	h := &Handler{service: svc, adminToken: "secret", configPath: path}
+
+	r := httptest.NewRequest(http.MethodPut, "/admin/api/channels/1?token=secret", strings.NewReader(`{"type": "ranking", "rid": 36}`))
+	r.SetPathValue("index", "1")
+	w := httptest.NewRecorder()
+	h.ChannelAPIHandler(w, r)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body = %s", w.Code, w.Body)
+	}
+
+	var resp channelsResponse
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Channels) != 2 || resp.Channels[0].Mid != "https://space.bilibili.com/946974" || resp.Channels[1].Rid != 36 {
+		t.Errorf("channels = %+v, want 配置文件中的写法", resp.Channels)
+	}
+}
@@ -0,0 +1,78 @@
+// Package api 提供专栏文章处理器
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// ArticlesTemplateData 传递给专栏模板的数据
+type ArticlesTemplateData struct {
+	Articles      models.ArticleList
+	CollapseAfter int
+}
+
+// fetchArticles 根据查询参数获取专栏文章
+func (h *Handler) fetchArticles(r *http.Request) (models.ArticleList, error) {
+	query := r.URL.Query()
+	limit := h.parseLimit(query)
+	cacheTTL := h.parseCacheTTL(query)
+
+	if mid := query.Get("mid"); mid != "" {
+		return h.service.FetchChannelArticles(mid, limit, cacheTTL)
+	}
+	return h.service.FetchAllArticles(limit, cacheTTL)
+}
+
+// ArticlesHandler 处理专栏文章请求
+func (h *Handler) ArticlesHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	collapseAfter := h.parseCollapseAfter(query, 5)
+
+	articles, err := h.fetchArticles(r)
+	if err != nil {
+		logger.Errorw("获取专栏失败",
+			"error", err,
+		)
+		http.Error(w, "获取专栏失败: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := ArticlesTemplateData{
+		Articles:      articles,
+		CollapseAfter: collapseAfter,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Widget-Title", "Bilibili 专栏")
+	w.Header().Set("Widget-Title-URL", "https://www.bilibili.com/read/home")
+	w.Header().Set("Widget-Content-Type", "html")
+	w.Header().Set("Widget-Content-Frameless", "false")
+	if err := h.templates["articles"].Execute(w, data); err != nil {
+		logger.Errorw("渲染专栏模板失败",
+			"error", err,
+		)
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+	}
+}
+
+// ArticlesJSONHandler 以 JSON 格式输出专栏文章
+func (h *Handler) ArticlesJSONHandler(w http.ResponseWriter, r *http.Request) {
+	articles, err := h.fetchArticles(r)
+	if err != nil {
+		logger.Errorw("获取专栏失败",
+			"error", err,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(articles)
+}
@@ -0,0 +1,87 @@
+// Package api 提供 UP 主频道资料处理器
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+	"glance-bilibili/internal/service"
+)
+
+// ChannelsTemplateData 传递给频道资料模板的数据
+type ChannelsTemplateData struct {
+	Channels      models.ChannelList
+	CollapseAfter int
+}
+
+// fetchChannels 根据查询参数获取频道资料
+// 频道资料变化很慢，cache 缺省时使用较长的默认缓存时间；sort=follower 时按粉丝数排序
+func (h *Handler) fetchChannels(r *http.Request) (models.ChannelList, error) {
+	query := r.URL.Query()
+
+	cacheTTL := service.DefaultChannelCacheTTL
+	if query.Get("cache") != "" {
+		cacheTTL = h.parseCacheTTL(query)
+	}
+
+	channels, err := h.service.FetchChannels(cacheTTL)
+	if err != nil {
+		return nil, err
+	}
+	if query.Get("sort") == "follower" {
+		channels = channels.SortByFollower()
+	}
+	return channels, nil
+}
+
+// ChannelsHandler 处理频道资料请求
+func (h *Handler) ChannelsHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	collapseAfter := h.parseCollapseAfter(query, 7)
+
+	channels, err := h.fetchChannels(r)
+	if err != nil {
+		logger.Errorw("获取频道资料失败",
+			"error", err,
+		)
+		http.Error(w, "获取频道资料失败: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := ChannelsTemplateData{
+		Channels:      channels,
+		CollapseAfter: collapseAfter,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Widget-Title", "Bilibili UP 主")
+	w.Header().Set("Widget-Title-URL", "https://www.bilibili.com")
+	w.Header().Set("Widget-Content-Type", "html")
+	w.Header().Set("Widget-Content-Frameless", "false")
+	if err := h.templates["channels"].Execute(w, data); err != nil {
+		logger.Errorw("渲染频道资料模板失败",
+			"error", err,
+		)
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+	}
+}
+
+// ChannelsJSONHandler 以 JSON 格式输出频道资料
+func (h *Handler) ChannelsJSONHandler(w http.ResponseWriter, r *http.Request) {
+	channels, err := h.fetchChannels(r)
+	if err != nil {
+		logger.Errorw("获取频道资料失败",
+			"error", err,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(channels)
+}
@@ -0,0 +1,250 @@
+// Package api 提供网页管理后台
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+	"glance-bilibili/internal/service"
+)
+
+// ConsolePage 管理后台的页面
+type ConsolePage struct {
+	Name  string // 页面名称，用于 URL
+	Title string // 导航中显示的标题
+}
+
+// consolePages 管理后台的页面，按导航顺序排列
+var consolePages = []ConsolePage{
+	{Name: "channels", Title: "频道"},
+	{Name: "groups", Title: "分组"},
+	{Name: "cache", Title: "缓存"},
+	{Name: "credential", Title: "登录凭据"},
+	{Name: "errors", Title: "抓取错误"},
+}
+
+// consoleSourceTypes 管理后台可添加的来源类型
+var consoleSourceTypes = []string{
+	config.SourceTypeUploads,
+	config.SourceTypeFavorite,
+	config.SourceTypeSeason,
+	config.SourceTypeSeries,
+	config.SourceTypePopular,
+	config.SourceTypeWeekly,
+	config.SourceTypeRanking,
+	config.SourceTypeBangumi,
+	config.SourceTypeArticle,
+	config.SourceTypeTimeline,
+	config.SourceTypeWatchLater,
+	config.SourceTypeHistory,
+}
+
+// ConsoleData 管理后台模板数据，各页面只使用其中一部分
+type ConsoleData struct {
+	Page  string
+	Title string
+	Token string
+	Error string // 页面数据加载失败时的错误信息
+	Pages []ConsolePage
+
+	// 频道与分组
+	Channels    []config.ChannelInfo
+	Groups      []GroupLink
+	Members     []string // 可加入分组的成员名称
+	SourceTypes []string
+
+	// 缓存
+	Cache    []models.CacheSection
+	CacheTTL int
+
+	// 登录凭据
+	Login          models.LoginStatus
+	CredentialPath string
+	Pool           models.PoolStatus
+
+	// 抓取错误
+	Errors    []models.FetchError
+	MaxErrors int
+}
+
+// ConsoleHandler 网页管理后台
+// GET /admin/console/{page}?token=<token>，/admin/console 跳转到频道页面
+func (h *Handler) ConsoleHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	name := r.PathValue("page")
+	if name == "" {
+		target := "/admin/console/" + consolePages[0].Name
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusFound)
+		return
+	}
+
+	tmpl, ok := h.templates["admin-"+name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	data := ConsoleData{
+		Page:  name,
+		Token: r.URL.Query().Get("token"),
+		Pages: consolePages,
+	}
+	for _, p := range consolePages {
+		if p.Name == name {
+			data.Title = p.Title
+		}
+	}
+	h.loadConsoleData(r, &data)
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := tmpl.Execute(w, data); err != nil {
+		logger.Errorw("渲染管理后台失败",
+			"page", name,
+			"error", err,
+		)
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+	}
+}
+
+// loadConsoleData 加载页面所需的数据
+// 频道与分组读取配置文件而非当前生效的配置，与管理接口修改的内容保持一致
+// 频道页显示 mid 的原写法，编辑未修改 mid 的频道时保持不变；分组按解析后的 mid 匹配成员
+func (h *Handler) loadConsoleData(r *http.Request, data *ConsoleData) {
+	switch data.Page {
+	case "channels":
+		channels, err := h.service.ListChannels(h.configPath)
+		if err != nil {
+			data.Error = "读取配置文件失败: " + err.Error()
+			return
+		}
+		data.Channels = channels
+		data.SourceTypes = consoleSourceTypes
+	case "groups":
+		cfg, err := h.service.ConfigFile(h.configPath)
+		if err != nil {
+			data.Error = "读取配置文件失败: " + err.Error()
+			return
+		}
+		data.Groups = groupLinks(cfg, baseURL(r))
+		data.Members = groupMembers(cfg)
+	case "cache":
+		data.Cache = h.service.CacheStatus()
+		data.CacheTTL = h.cacheTTL
+	case "credential":
+		data.Login = h.service.LoginStatus()
+		data.CredentialPath = h.service.CredentialPath()
+		data.Pool = h.service.PoolStatus()
+	case "errors":
+		data.Errors = h.service.RecentFetchErrors()
+		data.MaxErrors = service.MaxFetchErrors
+	}
+}
+
+// groupMembers 返回可加入分组的成员名称：频道的 name（未命名时为 mid）与关键词订阅的 name
+func groupMembers(cfg *config.Config) []string {
+	members := make([]string, 0, len(cfg.Channels)+len(cfg.Searches))
+	for _, ch := range cfg.Channels {
+		switch {
+		case ch.Name != "":
+			members = append(members, ch.Name)
+		case ch.Mid != "":
+			members = append(members, ch.Mid)
+		}
+	}
+	for _, s := range cfg.Searches {
+		members = append(members, s.Name)
+	}
+	return members
+}
+
+// sourceTypeLabel 返回来源类型的显示名称
+func sourceTypeLabel(t string) string {
+	switch t {
+	case config.SourceTypeUploads:
+		return "UP 主投稿"
+	case config.SourceTypeFavorite:
+		return "收藏夹"
+	case config.SourceTypeSeason:
+		return "合集"
+	case config.SourceTypeSeries:
+		return "系列"
+	case config.SourceTypePopular:
+		return "综合热门"
+	case config.SourceTypeWeekly:
+		return "每周必看"
+	case config.SourceTypeRanking:
+		return "排行榜"
+	case config.SourceTypeSearch:
+		return "关键词搜索"
+	case config.SourceTypeBangumi:
+		return "番剧"
+	case config.SourceTypeArticle:
+		return "专栏文章"
+	case config.SourceTypeTimeline:
+		return "关注时间线"
+	case config.SourceTypeWatchLater:
+		return "稍后再看"
+	case config.SourceTypeHistory:
+		return "观看历史"
+	default:
+		return t
+	}
+}
+
+// channelTarget 返回来源指向的对象描述，如 mid、收藏夹 ID 或分区
+func channelTarget(ch config.ChannelInfo) string {
+	switch ch.SourceType() {
+	case config.SourceTypeFavorite:
+		return "media_id " + ch.MediaID
+	case config.SourceTypeSeason:
+		return "mid " + ch.Mid + " / season_id " + ch.SeasonID
+	case config.SourceTypeSeries:
+		return "mid " + ch.Mid + " / series_id " + ch.SeriesID
+	case config.SourceTypeWeekly:
+		if ch.Number == 0 {
+			return "最新一期"
+		}
+		return "第 " + strconv.Itoa(ch.Number) + " 期"
+	case config.SourceTypeRanking:
+		if ch.Rid == 0 {
+			return "全站"
+		}
+		return "rid " + strconv.Itoa(ch.Rid)
+	case config.SourceTypeBangumi:
+		return "season_id " + ch.SeasonID
+	case config.SourceTypeUploads, config.SourceTypeArticle:
+		return "mid " + ch.Mid
+	default:
+		return "-"
+	}
+}
+
+// cacheKindLabel 返回缓存与抓取错误类型的显示名称
+func cacheKindLabel(kind string) string {
+	switch kind {
+	case "videos":
+		return "视频列表"
+	case "live":
+		return "直播状态"
+	case "dynamics":
+		return "动态"
+	case "articles":
+		return "专栏文章"
+	case "channels":
+		return "频道资料"
+	case "details":
+		return "视频详情"
+	default:
+		return kind
+	}
+}
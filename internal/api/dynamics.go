@@ -0,0 +1,101 @@
+// Package api 提供动态处理器
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// DynamicsTemplateData 传递给动态模板的数据
+type DynamicsTemplateData struct {
+	Items         models.FeedItemList
+	CollapseAfter int
+}
+
+// feedTypeLabel 返回动态类型的显示名称
+func feedTypeLabel(t string) string {
+	switch t {
+	case models.FeedItemTypeVideo:
+		return "投稿视频"
+	case models.FeedItemTypeDraw:
+		return "图文"
+	case models.FeedItemTypeForward:
+		return "转发"
+	case models.FeedItemTypeArticle:
+		return "专栏"
+	case models.FeedItemTypeLive:
+		return "直播"
+	default:
+		return "动态"
+	}
+}
+
+// fetchDynamics 根据查询参数获取动态
+// types 参数为逗号分隔的动态类型，如 types=draw,forward
+func (h *Handler) fetchDynamics(r *http.Request) (models.FeedItemList, error) {
+	query := r.URL.Query()
+	limit := h.parseLimit(query)
+	cacheTTL := h.parseCacheTTL(query)
+
+	var types []string
+	if t := query.Get("types"); t != "" {
+		types = strings.Split(t, ",")
+	}
+
+	if mid := query.Get("mid"); mid != "" {
+		return h.service.FetchChannelDynamics(mid, limit, types, cacheTTL)
+	}
+	return h.service.FetchAllDynamics(limit, types, cacheTTL)
+}
+
+// DynamicsHandler 处理动态列表请求
+func (h *Handler) DynamicsHandler(w http.ResponseWriter, r *http.Request) {
+	collapseAfter := h.parseCollapseAfter(r.URL.Query(), 5)
+
+	items, err := h.fetchDynamics(r)
+	if err != nil {
+		logger.Errorw("获取动态失败",
+			"error", err,
+		)
+		http.Error(w, "获取动态失败: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := DynamicsTemplateData{
+		Items:         items,
+		CollapseAfter: collapseAfter,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Widget-Title", "Bilibili 动态")
+	w.Header().Set("Widget-Title-URL", "https://t.bilibili.com")
+	w.Header().Set("Widget-Content-Type", "html")
+	w.Header().Set("Widget-Content-Frameless", "false")
+	if err := h.templates["dynamics"].Execute(w, data); err != nil {
+		logger.Errorw("渲染动态模板失败",
+			"error", err,
+		)
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+	}
+}
+
+// DynamicsJSONHandler 以 JSON 格式输出动态
+func (h *Handler) DynamicsJSONHandler(w http.ResponseWriter, r *http.Request) {
+	items, err := h.fetchDynamics(r)
+	if err != nil {
+		logger.Errorw("获取动态失败",
+			"error", err,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(items)
+}
@@ -3,9 +3,13 @@ package api
 
 import (
 	"embed"
+	"errors"
+	"fmt"
 	"html/template"
 	"net/http"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"encoding/json"
@@ -17,9 +21,21 @@ import (
 
 // HelpData 帮助页面模板数据
 type HelpData struct {
-	Channels     []config.ChannelInfo
-	DefaultLimit int
-	DefaultStyle string
+	Channels        []config.ChannelInfo
+	Searches        []config.SearchInfo
+	Groups          []GroupLink
+	DefaultLimit    int
+	DefaultCacheTTL int
+	DefaultStyle    string
+	Login           models.LoginStatus
+}
+
+// GroupLink 帮助页面中展示的频道分组及其访问地址
+type GroupLink struct {
+	config.GroupInfo
+	Sources int    // 分组内参与汇总的来源数量
+	URL     string // HTML 组件地址
+	JSONURL string // JSON 地址
 }
 
 // Handler HTTP 处理器
@@ -28,6 +44,12 @@ type Handler struct {
 	templates    map[string]*template.Template
 	defaultLimit int
 	defaultStyle string
+	adminToken   string // 管理接口令牌，为空时管理接口关闭
+	configPath   string // 管理接口写入的配置文件路径
+
+	cacheTTL          int // 默认缓存时间（秒），0 为禁用缓存
+	collapseAfter     int // 默认折叠数量，0 表示使用各页面自己的默认值
+	collapseAfterRows int // grid 布局默认折叠行数
 }
 
 // TemplateData 传递给模板的数据
@@ -42,18 +64,41 @@ const (
 	DefaultStyle = "horizontal-cards"
 )
 
-// NewHandler 创建处理器
-func NewHandler(svc *service.VideoService, templatesFS embed.FS, defaultLimit int) (*Handler, error) {
+// errPaginationUnsupported 当前查询模式不支持翻页
+var errPaginationUnsupported = errors.New("当前模式不支持翻页，page 仅支持单个 UP 主与汇总模式，cursor 仅支持单个 UP 主模式")
+
+// errUnsupportedSource source 参数不是支持的来源类型
+var errUnsupportedSource = errors.New("不支持的 source，可选值: " + strings.Join([]string{
+	config.SourceTypePopular, config.SourceTypeWeekly, config.SourceTypeRanking,
+	config.SourceTypeTimeline, config.SourceTypeWatchLater, config.SourceTypeHistory,
+}, ", "))
+
+// isBadRequest 判断错误是否由请求参数不合法导致
+func isBadRequest(err error) bool {
+	return errors.Is(err, errPaginationUnsupported) || errors.Is(err, errUnsupportedSource) ||
+		errors.Is(err, service.ErrInvalidCursor) || errors.Is(err, service.ErrPageOutOfRange)
+}
+
+// NewHandler 创建处理器，settings 为生效的全局设置
+func NewHandler(svc *service.VideoService, templatesFS embed.FS, settings config.Settings) (*Handler, error) {
 	h := &Handler{
-		service:      svc,
-		templates:    make(map[string]*template.Template),
-		defaultLimit: defaultLimit,
-		defaultStyle: DefaultStyle,
+		service:           svc,
+		templates:         make(map[string]*template.Template),
+		defaultLimit:      settings.Render.Limit,
+		defaultStyle:      DefaultStyle,
+		cacheTTL:          max(settings.Cache.TTL.Seconds(), 0),
+		collapseAfter:     settings.Render.CollapseAfter,
+		collapseAfterRows: settings.Render.CollapseAfterRows,
 	}
 
 	funcMap := template.FuncMap{
-		"relativeTime": relativeTime,
-		"safeURL":      func(s string) template.URL { return template.URL(s) },
+		"relativeTime":    relativeTime,
+		"safeURL":         func(s string) template.URL { return template.URL(s) },
+		"feedTypeLabel":   feedTypeLabel,
+		"formatCount":     formatCount,
+		"sourceTypeLabel": sourceTypeLabel,
+		"channelTarget":   channelTarget,
+		"cacheKindLabel":  cacheKindLabel,
 	}
 
 	// 加载模板
@@ -72,7 +117,37 @@ func NewHandler(svc *service.VideoService, templatesFS embed.FS, defaultLimit in
 	}
 
 	h.templates["vertical-list"], err = template.New("videos-list.html").Funcs(funcMap).ParseFS(
-		templatesFS, "templates/videos-list.html")
+		templatesFS, "templates/videos-list.html", "templates/video-card.html")
+	if err != nil {
+		return nil, err
+	}
+
+	h.templates["live"], err = template.New("live.html").Funcs(funcMap).ParseFS(
+		templatesFS, "templates/live.html")
+	if err != nil {
+		return nil, err
+	}
+
+	h.templates["dynamics"], err = template.New("dynamics.html").Funcs(funcMap).ParseFS(
+		templatesFS, "templates/dynamics.html")
+	if err != nil {
+		return nil, err
+	}
+
+	h.templates["articles"], err = template.New("articles.html").Funcs(funcMap).ParseFS(
+		templatesFS, "templates/articles.html")
+	if err != nil {
+		return nil, err
+	}
+
+	h.templates["channels"], err = template.New("channels.html").Funcs(funcMap).ParseFS(
+		templatesFS, "templates/channels.html")
+	if err != nil {
+		return nil, err
+	}
+
+	h.templates["login"], err = template.New("login.html").Funcs(funcMap).ParseFS(
+		templatesFS, "templates/login.html")
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +158,16 @@ func NewHandler(svc *service.VideoService, templatesFS embed.FS, defaultLimit in
 		return nil, err
 	}
 
+	// 管理后台各页面共用 admin-layout.html 中的页头与脚本
+	for _, page := range consolePages {
+		name := "admin-" + page.Name + ".html"
+		h.templates["admin-"+page.Name], err = template.New(name).Funcs(funcMap).ParseFS(
+			templatesFS, "templates/"+name, "templates/admin-layout.html")
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return h, nil
 }
 
@@ -105,56 +190,247 @@ func relativeTime(t time.Time) string {
 	}
 }
 
-// VideosHandler 处理视频列表请求
-func (h *Handler) VideosHandler(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
-
-	// 解析参数（URL 参数可覆盖默认值）
-	limit := h.defaultLimit
+// parseLimit 解析 limit 参数，缺省或非法时使用默认值
+func (h *Handler) parseLimit(query url.Values) int {
 	if limitStr := query.Get("limit"); limitStr != "" {
 		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+			return l
 		}
 	}
+	return h.defaultLimit
+}
 
-	style := h.defaultStyle
-	if s := query.Get("style"); s != "" {
-		style = s
+// parseCacheTTL 解析 cache 参数（秒），缺省时使用配置的缓存时间（默认 5 分钟），0 为禁用
+func (h *Handler) parseCacheTTL(query url.Values) int {
+	if cStr := query.Get("cache"); cStr != "" {
+		if c, err := strconv.Atoi(cStr); err == nil && c >= 0 {
+			return c
+		}
 	}
+	return h.cacheTTL
+}
 
-	collapseAfter := 7
+// parseCollapseAfter 解析 collapse-after 参数
+// 缺省时使用配置的 render.collapse_after，未配置时使用页面自己的默认值 pageDefault
+func (h *Handler) parseCollapseAfter(query url.Values, pageDefault int) int {
 	if ca := query.Get("collapse-after"); ca != "" {
 		if v, err := strconv.Atoi(ca); err == nil && v > 0 {
-			collapseAfter = v
+			return v
 		}
 	}
+	if h.collapseAfter > 0 {
+		return h.collapseAfter
+	}
+	return pageDefault
+}
 
-	collapseAfterRows := 4
-	if car := query.Get("collapse-after-rows"); car != "" {
-		if v, err := strconv.Atoi(car); err == nil && v > 0 {
-			collapseAfterRows = v
-		}
+// parseStats 解析数据补全参数，返回需要补全的视频数量
+// stats=true 补全全部结果，stats=N 仅补全前 N 个，缺省时不补全
+func parseStats(query url.Values, limit int) int {
+	statsStr := query.Get("stats")
+	if statsStr == "" {
+		return 0
+	}
+	if n, err := strconv.Atoi(statsStr); err == nil {
+		return min(max(n, 0), limit)
+	}
+	if enabled, err := strconv.ParseBool(statsStr); err == nil && enabled {
+		return limit
 	}
+	return 0
+}
 
-	cacheTTL := 300 // 默认 5 分钟
-	if cStr := query.Get("cache"); cStr != "" {
+// parseStatsCacheTTL 解析视频详情缓存时间参数，缺省时使用较长的默认值
+func parseStatsCacheTTL(query url.Values) int {
+	if cStr := query.Get("stats_cache"); cStr != "" {
 		if c, err := strconv.Atoi(cStr); err == nil && c >= 0 {
-			cacheTTL = c
+			return c
 		}
 	}
+	return service.DefaultStatsCacheTTL
+}
 
-	// 检查是否有临时指定的单个 mid
-	var videos models.VideoList
-	var err error
+// enrichVideos 按查询参数为视频补全互动数据
+func (h *Handler) enrichVideos(query url.Values, videos models.VideoList) models.VideoList {
+	topN := parseStats(query, len(videos))
+	if topN == 0 {
+		return videos
+	}
+	return h.service.EnrichVideos(videos, topN, parseStatsCacheTTL(query))
+}
+
+// hideWatched 按查询参数 hide_watched 移除已看完的视频
+func (h *Handler) hideWatched(query url.Values, videos models.VideoList, cacheTTL int) models.VideoList {
+	if hide, _ := strconv.ParseBool(query.Get("hide_watched")); !hide {
+		return videos
+	}
+	return h.service.HideWatched(videos, cacheTTL)
+}
+
+// formatCount 将数量格式化为简短形式，如 12345 -> 1.2万
+func formatCount(n int) string {
+	switch {
+	case n >= 100000000:
+		return strconv.FormatFloat(float64(n)/100000000, 'f', 1, 64) + "亿"
+	case n >= 10000:
+		return strconv.FormatFloat(float64(n)/10000, 'f', 1, 64) + "万"
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// 视频查询模式，由查询参数决定
+const (
+	modeAll      = "all"      // 多 UP 主汇总
+	modeHot      = "hot"      // 热门类来源: source=popular/weekly/ranking
+	modeSearch   = "search"   // 关键词订阅: search=<name>
+	modeBangumi  = "bangumi"  // 单部番剧: bangumi=<season_id>
+	modeSeason   = "season"   // 单个合集: mid + season_id
+	modeSeries   = "series"   // 单个系列: mid + series_id
+	modeChannel  = "channel"  // 单个 UP 主: mid
+	modeFavorite = "favorite" // 单个收藏夹: media_id
+	modePersonal = "personal" // 登录用户的个人来源: source=timeline/watchlater/history
+	modeInvalid  = "invalid"  // 不支持的 source
+)
+
+// videoMode 根据查询参数判断视频查询模式
+func videoMode(query url.Values) string {
+	mid := query.Get("mid")
+	source := config.ChannelInfo{Type: query.Get("source")}
+	switch {
+	case source.IsPersonal():
+		return modePersonal
+	case source.IsRanked():
+		return modeHot
+	case source.Type != "":
+		return modeInvalid
+	case query.Get("search") != "":
+		return modeSearch
+	case query.Get("bangumi") != "":
+		return modeBangumi
+	case mid != "" && query.Get("season_id") != "":
+		return modeSeason
+	case mid != "" && query.Get("series_id") != "":
+		return modeSeries
+	case mid != "":
+		return modeChannel
+	case query.Get("media_id") != "":
+		return modeFavorite
+	default:
+		return modeAll
+	}
+}
+
+// fetchVideos 根据查询参数选择视频来源，group 不为空时汇总该分组内的来源并忽略来源类参数
+func (h *Handler) fetchVideos(group string, query url.Values, limit int, cacheTTL int) (models.VideoList, error) {
+	if group != "" {
+		return h.service.FetchAllVideos(group, limit, cacheTTL)
+	}
 
-	if mid := query.Get("mid"); mid != "" {
-		// 单个 UP 主模式
-		videos, err = h.service.FetchChannelVideos(mid, limit, cacheTTL)
-	} else {
-		// 多 UP 主汇总模式
-		videos, err = h.service.FetchAllVideos(limit, cacheTTL)
+	switch videoMode(query) {
+	case modeInvalid:
+		return nil, errUnsupportedSource
+	case modeHot:
+		rid, _ := strconv.Atoi(query.Get("rid"))
+		number, _ := strconv.Atoi(query.Get("number"))
+		return h.service.FetchHotVideos(query.Get("source"), rid, number, limit, cacheTTL)
+	case modePersonal:
+		return h.service.FetchPersonalVideos(query.Get("source"), limit, cacheTTL)
+	case modeSearch:
+		return h.service.FetchSearchVideos(query.Get("search"), limit, cacheTTL)
+	case modeBangumi:
+		return h.service.FetchBangumiEpisodes(query.Get("bangumi"), limit, cacheTTL)
+	case modeSeason:
+		return h.service.FetchSeasonVideos(query.Get("mid"), query.Get("season_id"), limit, cacheTTL)
+	case modeSeries:
+		return h.service.FetchSeriesVideos(query.Get("mid"), query.Get("series_id"), limit, cacheTTL)
+	case modeChannel:
+		return h.service.FetchChannelVideos(query.Get("mid"), limit, cacheTTL)
+	case modeFavorite:
+		return h.service.FetchFavoriteVideos(query.Get("media_id"), query.Get("order"), limit, cacheTTL)
+	default:
+		return h.service.FetchAllVideos("", limit, cacheTTL)
+	}
+}
+
+// fetchVideoPage 根据查询参数获取一页视频
+// page（从 1 开始）与 cursor 用于翻页；cursor 仅支持单个 UP 主模式，page 支持单个 UP 主与汇总模式（含分组）
+func (h *Handler) fetchVideoPage(group string, query url.Values, limit int, cacheTTL int) (models.VideoPage, error) {
+	page := 1
+	if p, err := strconv.Atoi(query.Get("page")); err == nil && p > 0 {
+		page = p
+	}
+	cursor := query.Get("cursor")
+
+	mode := videoMode(query)
+	if group != "" {
+		mode = modeAll
+	}
+	switch {
+	case mode == modeInvalid:
+		return models.VideoPage{}, errUnsupportedSource
+	case mode == modeChannel:
+		return h.service.FetchChannelVideosPage(query.Get("mid"), page, cursor, limit, cacheTTL)
+	case mode == modeAll && cursor == "" && page > 1:
+		return h.service.FetchAllVideosPage(group, page, limit, cacheTTL)
+	case cursor != "" || page > 1:
+		return models.VideoPage{}, errPaginationUnsupported
 	}
 
+	videos, err := h.fetchVideos(group, query, limit, cacheTTL)
+	if err != nil {
+		return models.VideoPage{}, err
+	}
+	result := models.VideoPage{Videos: videos, Page: 1}
+	if mode == modeAll && len(videos) >= limit {
+		result.NextPage = 2
+	}
+	return result, nil
+}
+
+// setPageHeaders 通过响应头返回翻页信息
+func setPageHeaders(w http.ResponseWriter, page models.VideoPage) {
+	if page.NextPage > 0 {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page.NextPage))
+	}
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+}
+
+// VideosHandler 处理视频列表请求
+func (h *Handler) VideosHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	// 解析参数（URL 参数可覆盖默认值）
+	limit := h.parseLimit(query)
+
+	style := h.defaultStyle
+	if s := query.Get("style"); s != "" {
+		style = s
+	}
+
+	collapseAfter := h.parseCollapseAfter(query, 7)
+
+	collapseAfterRows := h.collapseAfterRows
+	if car := query.Get("collapse-after-rows"); car != "" {
+		if v, err := strconv.Atoi(car); err == nil && v > 0 {
+			collapseAfterRows = v
+		}
+	}
+
+	cacheTTL := h.parseCacheTTL(query)
+
+	group := r.PathValue("group")
+	page, err := h.fetchVideoPage(group, query, limit, cacheTTL)
+	if isBadRequest(err) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, service.ErrGroupNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
 	if err != nil {
 		logger.Errorw("获取视频失败",
 			"error", err,
@@ -165,7 +441,7 @@ func (h *Handler) VideosHandler(w http.ResponseWriter, r *http.Request) {
 
 	// 准备模板数据
 	data := TemplateData{
-		Videos:            videos,
+		Videos:            h.service.AttachAvatars(h.enrichVideos(query, h.hideWatched(query, page.Videos, cacheTTL))),
 		Style:             style,
 		CollapseAfter:     collapseAfter,
 		CollapseAfterRows: collapseAfterRows,
@@ -177,8 +453,9 @@ func (h *Handler) VideosHandler(w http.ResponseWriter, r *http.Request) {
 		tmpl = h.templates["horizontal-cards"]
 	}
 
+	setPageHeaders(w, page)
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
-	w.Header().Set("Widget-Title", "Bilibili")
+	w.Header().Set("Widget-Title", h.widgetTitle(group))
 	w.Header().Set("Widget-Title-URL", "https://www.bilibili.com")
 	w.Header().Set("Widget-Content-Type", "html")
 	frameless := "true"
@@ -195,51 +472,102 @@ func (h *Handler) VideosHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-// JSONHandler 以 JSON 格式输出排序后的视频列表
-func (h *Handler) JSONHandler(w http.ResponseWriter, r *http.Request) {
-	query := r.URL.Query()
-
-	limit := h.defaultLimit
-	if limitStr := query.Get("limit"); limitStr != "" {
-		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
-			limit = l
+// widgetTitle 返回 Glance 组件标题，分组设置了 title 时使用分组标题
+func (h *Handler) widgetTitle(group string) string {
+	if group != "" {
+		if g, ok := h.service.GetConfig().FindGroup(group); ok && g.Title != "" {
+			return g.Title
 		}
 	}
+	return "Bilibili"
+}
 
-	cacheTTL := 300
-	if cStr := query.Get("cache"); cStr != "" {
-		if c, err := strconv.Atoi(cStr); err == nil && c >= 0 {
-			cacheTTL = c
-		}
-	}
+// JSONHandler 以 JSON 格式输出排序后的视频列表
+func (h *Handler) JSONHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
 
-	var videos models.VideoList
-	var err error
+	limit := h.parseLimit(query)
 
-	if mid := query.Get("mid"); mid != "" {
-		videos, err = h.service.FetchChannelVideos(mid, limit, cacheTTL)
-	} else {
-		videos, err = h.service.FetchAllVideos(limit, cacheTTL)
-	}
+	cacheTTL := h.parseCacheTTL(query)
 
+	page, err := h.fetchVideoPage(r.PathValue("group"), query, limit, cacheTTL)
 	if err != nil {
-		logger.Errorw("获取视频失败",
-			"error", err,
-		)
+		status := http.StatusInternalServerError
+		if isBadRequest(err) {
+			status = http.StatusBadRequest
+		} else if errors.Is(err, service.ErrGroupNotFound) {
+			status = http.StatusNotFound
+		} else {
+			logger.Errorw("获取视频失败",
+				"error", err,
+			)
+		}
 		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(status)
 		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
 		return
 	}
 
+	setPageHeaders(w, page)
 	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(videos)
+	json.NewEncoder(w).Encode(h.service.AttachAvatars(h.enrichVideos(query, h.hideWatched(query, page.Videos, cacheTTL))))
 }
 
 // HealthHandler 健康检查
+// 响应固定为 OK，便于按内容匹配的健康检查；?verbose=1 时在第二行附加登录状态
 func (h *Handler) HealthHandler(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("OK"))
+	if r.URL.Query().Get("verbose") != "1" {
+		w.Write([]byte("OK"))
+		return
+	}
+	w.Write([]byte("OK\n" + loginStatusText(h.service.LoginStatus()) + "\n"))
+}
+
+// baseURL 根据请求推断服务的访问地址，反向代理可通过 X-Forwarded-Proto 与 X-Forwarded-Host 指定
+func baseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	host := r.Host
+	if fwd := r.Header.Get("X-Forwarded-Host"); fwd != "" {
+		host = fwd
+	}
+	return scheme + "://" + host
+}
+
+// groupLinks 生成帮助页面中各分组的访问地址
+func groupLinks(cfg *config.Config, base string) []GroupLink {
+	links := make([]GroupLink, 0, len(cfg.Groups))
+	for _, g := range cfg.Groups {
+		feed := base + "/feed/" + url.PathEscape(g.Name)
+		links = append(links, GroupLink{
+			GroupInfo: g,
+			Sources:   len(cfg.GroupSources(g)),
+			URL:       feed,
+			JSONURL:   feed + "/json",
+		})
+	}
+	return links
+}
+
+// loginStatusText 返回登录状态的文字描述
+func loginStatusText(status models.LoginStatus) string {
+	switch {
+	case status.LoggedIn:
+		return fmt.Sprintf("login: logged in as %s (mid %s)", status.Uname, status.Mid)
+	case !status.Configured:
+		return "login: anonymous"
+	case status.CheckedAt.IsZero():
+		return "login: credential configured, not verified yet"
+	default:
+		return "login: credential invalid or expired"
+	}
 }
 
 // HelpHandler 帮助说明页
@@ -253,9 +581,13 @@ func (h *Handler) HelpHandler(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
 
 	data := HelpData{
-		Channels:     cfg.Channels,
-		DefaultLimit: h.defaultLimit,
-		DefaultStyle: h.defaultStyle,
+		Channels:        cfg.Channels,
+		Searches:        cfg.Searches,
+		Groups:          groupLinks(cfg, baseURL(r)),
+		DefaultLimit:    h.defaultLimit,
+		DefaultCacheTTL: h.cacheTTL,
+		DefaultStyle:    h.defaultStyle,
+		Login:           h.service.LoginStatus(),
 	}
 
 	if err := h.templates["help"].Execute(w, data); err != nil {
@@ -2,8 +2,16 @@
 package api
 
 import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+	"glance-bilibili/internal/service"
 )
 
 // TestRelativeTime 测试相对时间计算
@@ -111,3 +119,216 @@ func TestDefaultStyle(t *testing.T) {
 		t.Errorf("DefaultStyle = %s, want horizontal-cards", DefaultStyle)
 	}
 }
+
+// TestVideoMode 测试根据查询参数判断视频查询模式
+func TestVideoMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		expected string
+	}{
+		{name: "无参数为汇总模式", query: "", expected: modeAll},
+		{name: "仅 mid 为单个 UP 主", query: "mid=1", expected: modeChannel},
+		{name: "mid 与合集", query: "mid=1&season_id=2", expected: modeSeason},
+		{name: "mid 与系列", query: "mid=1&series_id=2", expected: modeSeries},
+		{name: "收藏夹", query: "media_id=3", expected: modeFavorite},
+		{name: "热门来源优先", query: "source=popular&mid=1", expected: modeHot},
+		{name: "关键词订阅", query: "search=apple", expected: modeSearch},
+		{name: "番剧", query: "bangumi=123", expected: modeBangumi},
+		{name: "稍后再看", query: "source=watchlater", expected: modePersonal},
+		{name: "观看历史", query: "source=history", expected: modePersonal},
+		{name: "不支持的来源", query: "source=unknown&mid=1", expected: modeInvalid},
+		{name: "分页参数不影响模式", query: "mid=1&page=2&cursor=100_BV1", expected: modeChannel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, err := url.ParseQuery(tt.query)
+			if err != nil {
+				t.Fatalf("ParseQuery() error = %v", err)
+			}
+			if got := videoMode(query); got != tt.expected {
+				t.Errorf("videoMode(%q) = %s, want %s", tt.query, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestParseStats 测试数据补全参数解析
+func TestParseStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		limit    int
+		expected int
+	}{
+		{name: "缺省不补全", query: "", limit: 25, expected: 0},
+		{name: "true 补全全部", query: "stats=true", limit: 25, expected: 25},
+		{name: "false 不补全", query: "stats=false", limit: 25, expected: 0},
+		{name: "指定数量", query: "stats=5", limit: 25, expected: 5},
+		{name: "数量不超过结果数", query: "stats=50", limit: 25, expected: 25},
+		{name: "负数视为不补全", query: "stats=-1", limit: 25, expected: 0},
+		{name: "非法值不补全", query: "stats=abc", limit: 25, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, _ := url.ParseQuery(tt.query)
+			if got := parseStats(query, tt.limit); got != tt.expected {
+				t.Errorf("parseStats(%q) = %d, want %d", tt.query, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestFormatCount 测试数量简写
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		input    int
+		expected string
+	}{
+		{input: 0, expected: "0"},
+		{input: 9999, expected: "9999"},
+		{input: 12345, expected: "1.2万"},
+		{input: 150000000, expected: "1.5亿"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := formatCount(tt.input); got != tt.expected {
+				t.Errorf("formatCount(%d) = %s, want %s", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGroupLinks 测试帮助页面的分组访问地址
+func TestGroupLinks(t *testing.T) {
+	cfg := &config.Config{
+		Channels: []config.ChannelInfo{{Mid: "1"}, {Mid: "2"}},
+		Groups:   []config.GroupInfo{{Name: "tech", Channels: []string{"1", "2"}}},
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/help", nil)
+	r.Host = "nas.local:8082"
+	links := groupLinks(cfg, baseURL(r))
+	if len(links) != 1 || links[0].URL != "http://nas.local:8082/feed/tech" || links[0].JSONURL != "http://nas.local:8082/feed/tech/json" || links[0].Sources != 2 {
+		t.Errorf("groupLinks() = %+v", links)
+	}
+
+	r.Header.Set("X-Forwarded-Proto", "https")
+	r.Header.Set("X-Forwarded-Host", "bili.example.com")
+	if got := baseURL(r); got != "https://bili.example.com" {
+		t.Errorf("反向代理 baseURL() = %s", got)
+	}
+}
+
+// TestLoginStatusText 测试登录状态描述
+func TestLoginStatusText(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   models.LoginStatus
+		expected string
+	}{
+		{name: "未配置", status: models.LoginStatus{}, expected: "login: anonymous"},
+		{name: "未校验", status: models.LoginStatus{Configured: true}, expected: "login: credential configured, not verified yet"},
+		{name: "已失效", status: models.LoginStatus{Configured: true, CheckedAt: time.Now()}, expected: "login: credential invalid or expired"},
+		{name: "已登录", status: models.LoginStatus{Configured: true, LoggedIn: true, Uname: "u", Mid: "1", CheckedAt: time.Now()}, expected: "login: logged in as u (mid 1)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := loginStatusText(tt.status); got != tt.expected {
+				t.Errorf("loginStatusText() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestGroupMembers 测试管理后台分组可选成员
+func TestGroupMembers(t *testing.T) {
+	cfg := &config.Config{
+		Channels: []config.ChannelInfo{
+			{Mid: "1", Name: "科技"},
+			{Mid: "2"},
+			{Type: config.SourceTypePopular},
+			{Type: config.SourceTypeWeekly, Name: "每周必看"},
+		},
+		Searches: []config.SearchInfo{{Name: "mc", Keyword: "我的世界"}},
+	}
+
+	got := strings.Join(groupMembers(cfg), ",")
+	if expected := "科技,2,每周必看,mc"; got != expected {
+		t.Errorf("groupMembers() = %s, want %s", got, expected)
+	}
+}
+
+// TestChannelTarget 测试管理后台来源对象描述
+func TestChannelTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  config.ChannelInfo
+		expected string
+	}{
+		{name: "UP 主投稿", channel: config.ChannelInfo{Mid: "1"}, expected: "mid 1"},
+		{name: "收藏夹", channel: config.ChannelInfo{Type: config.SourceTypeFavorite, MediaID: "9"}, expected: "media_id 9"},
+		{name: "合集", channel: config.ChannelInfo{Type: config.SourceTypeSeason, Mid: "1", SeasonID: "2"}, expected: "mid 1 / season_id 2"},
+		{name: "全站排行榜", channel: config.ChannelInfo{Type: config.SourceTypeRanking}, expected: "全站"},
+		{name: "每周必看指定期数", channel: config.ChannelInfo{Type: config.SourceTypeWeekly, Number: 3}, expected: "第 3 期"},
+		{name: "关注时间线", channel: config.ChannelInfo{Type: config.SourceTypeTimeline}, expected: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := channelTarget(tt.channel); got != tt.expected {
+				t.Errorf("channelTarget() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestJSONHandler_BadRequest 测试请求参数不合法时返回 400
+func TestJSONHandler_BadRequest(t *testing.T) {
+	svc := service.NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer svc.Shutdown()
+	h := &Handler{service: svc, defaultLimit: config.DefaultLimit}
+
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{name: "无效游标", query: "mid=1&cursor=abc"},
+		{name: "汇总页码超出范围", query: "page=11"},
+		{name: "不支持翻页的模式", query: "source=popular&page=2"},
+		{name: "不支持的来源", query: "source=unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.JSONHandler(w, httptest.NewRequest(http.MethodGet, "/json?"+tt.query, nil))
+			if w.Code != http.StatusBadRequest {
+				t.Errorf("status = %d, want 400, body = %s", w.Code, w.Body)
+			}
+		})
+	}
+}
+
+// TestHealthHandler 测试健康检查默认只返回 OK，verbose 时附加登录状态
+func TestHealthHandler(t *testing.T) {
+	svc := service.NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer svc.Shutdown()
+	h := &Handler{service: svc}
+
+	w := httptest.NewRecorder()
+	h.HealthHandler(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	if w.Body.String() != "OK" {
+		t.Errorf("body = %q, want OK", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	h.HealthHandler(w, httptest.NewRequest(http.MethodGet, "/health?verbose=1", nil))
+	if lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n"); len(lines) != 2 || lines[0] != "OK" {
+		t.Errorf("verbose body = %q, want OK 与登录状态两行", w.Body.String())
+	}
+}
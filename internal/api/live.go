@@ -0,0 +1,79 @@
+// Package api 提供直播间状态处理器
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// LiveTemplateData 传递给直播模板的数据
+type LiveTemplateData struct {
+	Rooms         models.LiveRoomList
+	CollapseAfter int
+}
+
+// fetchLiveRooms 根据查询参数获取直播间状态
+// 默认只返回直播中的直播间，all=true 时返回全部
+func (h *Handler) fetchLiveRooms(r *http.Request) (models.LiveRoomList, error) {
+	query := r.URL.Query()
+	cacheTTL := h.parseCacheTTL(query)
+	onlyLive := query.Get("all") != "true"
+
+	if mid := query.Get("mid"); mid != "" {
+		return h.service.FetchChannelLiveRoom(mid, onlyLive, cacheTTL)
+	}
+	return h.service.FetchLiveRooms(onlyLive, cacheTTL)
+}
+
+// LiveHandler 处理直播间状态请求
+func (h *Handler) LiveHandler(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	collapseAfter := h.parseCollapseAfter(query, 7)
+
+	rooms, err := h.fetchLiveRooms(r)
+	if err != nil {
+		logger.Errorw("获取直播间状态失败",
+			"error", err,
+		)
+		http.Error(w, "获取直播间状态失败: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := LiveTemplateData{
+		Rooms:         rooms.Limit(h.parseLimit(query)),
+		CollapseAfter: collapseAfter,
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Widget-Title", "Bilibili 直播")
+	w.Header().Set("Widget-Title-URL", "https://live.bilibili.com")
+	w.Header().Set("Widget-Content-Type", "html")
+	w.Header().Set("Widget-Content-Frameless", "false")
+	if err := h.templates["live"].Execute(w, data); err != nil {
+		logger.Errorw("渲染直播模板失败",
+			"error", err,
+		)
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+	}
+}
+
+// LiveJSONHandler 以 JSON 格式输出直播间状态
+func (h *Handler) LiveJSONHandler(w http.ResponseWriter, r *http.Request) {
+	rooms, err := h.fetchLiveRooms(r)
+	if err != nil {
+		logger.Errorw("获取直播间状态失败",
+			"error", err,
+		)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(rooms.Limit(h.parseLimit(r.URL.Query())))
+}
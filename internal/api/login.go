@@ -0,0 +1,90 @@
+// Package api 提供扫码登录处理器
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"net/http"
+
+	"github.com/skip2/go-qrcode"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// qrCodeSize 登录二维码图片边长（像素）
+const qrCodeSize = 256
+
+// LoginData 扫码登录页模板数据
+type LoginData struct {
+	QRCode         template.URL // 二维码 PNG 的 data URI
+	Key            string
+	CredentialPath string
+	Login          models.LoginStatus
+}
+
+// LoginPageHandler 扫码登录页：申请二维码并在服务端渲染
+// GET /admin/login?token=<token>
+func (h *Handler) LoginPageHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	qr, err := h.service.StartQRLogin()
+	if err != nil {
+		logger.Errorw("申请登录二维码失败", "error", err)
+		http.Error(w, "申请登录二维码失败", http.StatusBadGateway)
+		return
+	}
+
+	png, err := qrcode.Encode(qr.URL, qrcode.Medium, qrCodeSize)
+	if err != nil {
+		logger.Errorw("生成登录二维码失败", "error", err)
+		http.Error(w, "生成登录二维码失败", http.StatusInternalServerError)
+		return
+	}
+
+	data := LoginData{
+		QRCode:         template.URL("data:image/png;base64," + base64.StdEncoding.EncodeToString(png)),
+		Key:            qr.Key,
+		CredentialPath: h.service.CredentialPath(),
+		Login:          h.service.LoginStatus(),
+	}
+
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	if err := h.templates["login"].Execute(w, data); err != nil {
+		logger.Errorw("渲染登录页面失败",
+			"error", err,
+		)
+		http.Error(w, "渲染失败", http.StatusInternalServerError)
+	}
+}
+
+// LoginPollHandler 查询扫码登录状态，确认登录后凭据立即生效
+// GET /admin/login/poll?key=<qrcode_key>&token=<token>
+func (h *Handler) LoginPollHandler(w http.ResponseWriter, r *http.Request) {
+	if !h.authorizeAdmin(w, r) {
+		return
+	}
+
+	key := r.URL.Query().Get("key")
+	if key == "" {
+		writeJSONError(w, http.StatusBadRequest, "缺少 key 参数")
+		return
+	}
+
+	state, err := h.service.PollQRLogin(key)
+	if err != nil {
+		logger.Errorw("查询扫码登录状态失败", "error", err)
+		writeJSONError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"state": state,
+		"login": h.service.LoginStatus(),
+	})
+}
@@ -6,17 +6,522 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strconv"
 )
 
-// Config 应用配置 (仅包含 UP 主通道信息)
+// Config 应用配置
 type Config struct {
-	Channels []ChannelInfo `json:"channels"` // UP 主配置列表
+	Server ServerConfig `json:"server,omitzero" yaml:"server,omitempty"` // HTTP 服务设置
+	Fetch  FetchConfig  `json:"fetch,omitzero" yaml:"fetch,omitempty"`   // 抓取设置
+	Cache  CacheConfig  `json:"cache,omitzero" yaml:"cache,omitempty"`   // 缓存设置
+	Render RenderConfig `json:"render,omitzero" yaml:"render,omitempty"` // 页面渲染设置
+
+	Channels   []ChannelInfo  `json:"channels" yaml:"channels"`                        // UP 主配置列表
+	Searches   []SearchInfo   `json:"searches,omitempty" yaml:"searches,omitempty"`    // 关键词订阅列表
+	Groups     []GroupInfo    `json:"groups,omitempty" yaml:"groups,omitempty"`        // 频道分组列表
+	Credential CredentialInfo `json:"credential,omitzero" yaml:"credential,omitempty"` // 登录凭据（可选）
+	Pool       PoolConfig     `json:"pool,omitzero" yaml:"pool,omitempty"`             // 请求身份池（可选）
+}
+
+// PoolConfig 请求身份池配置
+// 主身份（上面的 credential 或扫码登录凭据）始终在池中，identities 为额外身份
+type PoolConfig struct {
+	Strategy   string         `json:"strategy,omitempty" yaml:"strategy,omitempty"`     // 分配策略: round-robin（默认）、least-throttled
+	Identities []IdentityInfo `json:"identities,omitempty" yaml:"identities,omitempty"` // 额外身份列表
+}
+
+// IdentityInfo 额外的请求身份，未填写 Cookie 时以游客身份请求
+type IdentityInfo struct {
+	Name           string `json:"name,omitempty" yaml:"name,omitempty"` // 名称，为空时使用 identity-<序号>
+	CredentialInfo `yaml:",inline"`
+	UserAgent      string `json:"user_agent,omitempty" yaml:"user_agent,omitempty"` // 为空时使用默认 User-Agent
+}
+
+// 身份分配策略
+const (
+	PoolStrategyRoundRobin     = "round-robin"
+	PoolStrategyLeastThrottled = "least-throttled"
+)
+
+// IdentityName 返回身份名称，为空时按序号生成
+func (p PoolConfig) IdentityName(i int) string {
+	if name := p.Identities[i].Name; name != "" {
+		return name
+	}
+	return "identity-" + strconv.Itoa(i+1)
+}
+
+// CredentialInfo 登录凭据，取自浏览器 Cookie
+// 也可通过环境变量 SESSDATA、BILI_JCT、DEDEUSERID 设置，环境变量优先
+type CredentialInfo struct {
+	SESSDATA   string `json:"sessdata,omitempty" yaml:"sessdata,omitempty"`
+	BiliJct    string `json:"bili_jct,omitempty" yaml:"bili_jct,omitempty"`
+	DedeUserID string `json:"dedeuserid,omitempty" yaml:"dedeuserid,omitempty"`
 }
 
+// credentialsFile 扫码登录凭据文件名，位于配置文件同目录
+const credentialsFile = "credentials.json"
+
+// CredentialsPath 返回配置文件同目录下的扫码登录凭据文件路径
+func CredentialsPath(configPath string) string {
+	return filepath.Join(filepath.Dir(ResolvePath(configPath)), credentialsFile)
+}
+
+// HasEnvCredential 是否通过环境变量设置了登录凭据
+func HasEnvCredential() bool {
+	return os.Getenv("SESSDATA") != ""
+}
+
+// LoginCredential 返回实际使用的登录凭据：环境变量优先，其次为配置文件
+// 环境变量不会写回配置文件
+func (c *Config) LoginCredential() CredentialInfo {
+	cred := c.Credential
+	if v := os.Getenv("SESSDATA"); v != "" {
+		cred.SESSDATA = v
+	}
+	if v := os.Getenv("BILI_JCT"); v != "" {
+		cred.BiliJct = v
+	}
+	if v := os.Getenv("DEDEUSERID"); v != "" {
+		cred.DedeUserID = v
+	}
+	return cred
+}
+
+// SearchInfo 关键词订阅（按发布时间排序的搜索结果）
+type SearchInfo struct {
+	Name    string `json:"name" yaml:"name"`                       // 名称，用于 /?search=<name> 访问
+	Keyword string `json:"keyword" yaml:"keyword"`                 // 搜索关键词
+	Merge   bool   `json:"merge,omitempty" yaml:"merge,omitempty"` // 是否合并进汇总列表
+}
+
+// Source 将关键词订阅转换为统一的来源配置
+func (si SearchInfo) Source() ChannelInfo {
+	return ChannelInfo{
+		Type:    SourceTypeSearch,
+		Name:    si.Name,
+		Keyword: si.Keyword,
+	}
+}
+
+// GroupInfo 频道分组，通过 /feed/<name> 单独汇总分组内的来源
+type GroupInfo struct {
+	Name     string   `json:"name" yaml:"name"`                       // 分组名称，用于 URL，仅支持字母、数字、- 与 _
+	Title    string   `json:"title,omitempty" yaml:"title,omitempty"` // 显示名称，作为 Glance 组件标题
+	Channels []string `json:"channels" yaml:"channels"`               // 分组成员，按 channels 的 name 或 mid、searches 的 name 匹配
+}
+
+// groupNamePattern 分组名称格式
+var groupNamePattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// 内容来源类型
+const (
+	SourceTypeUploads    = "uploads"    // UP 主投稿（默认）
+	SourceTypeFavorite   = "favorite"   // 公开收藏夹
+	SourceTypeSeason     = "season"     // UP 主合集
+	SourceTypeSeries     = "series"     // UP 主系列
+	SourceTypePopular    = "popular"    // 综合热门
+	SourceTypeWeekly     = "weekly"     // 每周必看
+	SourceTypeRanking    = "ranking"    // 分区排行榜
+	SourceTypeSearch     = "search"     // 关键词搜索（由 searches 配置生成）
+	SourceTypeBangumi    = "bangumi"    // 番剧剧集更新
+	SourceTypeArticle    = "article"    // UP 主专栏文章
+	SourceTypeTimeline   = "timeline"   // 登录用户的关注时间线（需要登录凭据）
+	SourceTypeWatchLater = "watchlater" // 登录用户的稍后再看（需要登录凭据）
+	SourceTypeHistory    = "history"    // 登录用户的观看历史（需要登录凭据）
+)
+
+// 收藏夹排序方式
+const (
+	FavoriteOrderFavTime = "fav_time" // 按收藏时间（默认）
+	FavoriteOrderPubdate = "pubdate"  // 按视频发布时间
+)
+
 // ChannelInfo UP 主信息
 type ChannelInfo struct {
-	Mid  string `json:"mid"`  // 用户 UID
-	Name string `json:"name"` // 名称（可选，用于显示）
+	Mid     string `json:"mid" yaml:"mid"`                               // 用户 UID，也可填写主页链接、b23.tv 短链接或 @名称，加载时解析为 UID
+	Name    string `json:"name" yaml:"name"`                             // 名称（可选，用于显示）
+	Type    string `json:"type,omitempty" yaml:"type,omitempty"`         // 来源类型，默认为 uploads
+	MediaID string `json:"media_id,omitempty" yaml:"media_id,omitempty"` // 收藏夹 ID（type 为 favorite 时必填）
+	Order   string `json:"order,omitempty" yaml:"order,omitempty"`       // 收藏夹排序方式: fav_time/pubdate
+
+	SeasonID string `json:"season_id,omitempty" yaml:"season_id,omitempty"` // 合集 ID 或番剧 season_id（type 为 season/bangumi 时必填）
+	SeriesID string `json:"series_id,omitempty" yaml:"series_id,omitempty"` // 系列 ID（type 为 series 时必填）
+
+	Rid    int `json:"rid,omitempty" yaml:"rid,omitempty"`       // 排行榜分区 ID（type 为 ranking 时使用，0 为全站）
+	Number int `json:"number,omitempty" yaml:"number,omitempty"` // 每周必看期数（type 为 weekly 时使用，0 为最新一期）
+
+	Keyword string `json:"keyword,omitempty" yaml:"keyword,omitempty"` // 搜索关键词（type 为 search 时必填）
+
+	// 以下选项仅作用于汇总列表
+	Enabled       *bool `json:"enabled,omitempty" yaml:"enabled,omitempty"` // 是否启用，默认启用；停用后不参与汇总、直播、动态等功能
+	Limit         int   `json:"limit,omitempty" yaml:"limit,omitempty"`     // 该来源在汇总中最多贡献的视频数量，0 为不限制
+	FilterOptions `yaml:",inline"`
+}
+
+// FilterOptions 来源在汇总前的过滤条件，条件之间为“且”的关系
+type FilterOptions struct {
+	Include     []string `json:"include,omitempty" yaml:"include,omitempty"`           // 标题需匹配其中任一正则
+	Exclude     []string `json:"exclude,omitempty" yaml:"exclude,omitempty"`           // 标题匹配其中任一正则时排除
+	MinDuration Duration `json:"min_duration,omitempty" yaml:"min_duration,omitempty"` // 最短时长，时长未知的视频不受影响
+	MaxDuration Duration `json:"max_duration,omitempty" yaml:"max_duration,omitempty"` // 最长时长，时长未知的视频不受影响
+	Tids        []int    `json:"tids,omitempty" yaml:"tids,omitempty"`                 // 仅保留这些分区的视频，分区未知的视频不受影响
+	ExcludeTids []int    `json:"exclude_tids,omitempty" yaml:"exclude_tids,omitempty"` // 排除这些分区的视频
+}
+
+// IsZero 是否未设置任何过滤条件
+func (f FilterOptions) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0 && f.MinDuration == 0 && f.MaxDuration == 0 &&
+		len(f.Tids) == 0 && len(f.ExcludeTids) == 0
+}
+
+// validate 校验过滤条件
+func (f FilterOptions) validate() error {
+	for _, patterns := range [][]string{f.Include, f.Exclude} {
+		for _, p := range patterns {
+			if _, err := regexp.Compile(p); err != nil {
+				return fmt.Errorf("标题正则无效: %w", err)
+			}
+		}
+	}
+	if f.MinDuration < 0 || f.MaxDuration < 0 {
+		return fmt.Errorf("时长不能为负数")
+	}
+	if f.MaxDuration > 0 && f.MinDuration > f.MaxDuration {
+		return fmt.Errorf("min_duration 大于 max_duration")
+	}
+	return nil
+}
+
+// IsEnabled 是否启用该来源，未配置 enabled 时默认启用
+func (ch ChannelInfo) IsEnabled() bool {
+	return ch.Enabled == nil || *ch.Enabled
+}
+
+// SourceType 返回来源类型，未配置时视为 UP 主投稿
+func (ch ChannelInfo) SourceType() string {
+	if ch.Type == "" {
+		return SourceTypeUploads
+	}
+	return ch.Type
+}
+
+// CacheKey 返回该来源的缓存键
+// UP 主投稿直接使用 mid，以便与单个 mid 查询共享缓存
+func (ch ChannelInfo) CacheKey() string {
+	switch ch.SourceType() {
+	case SourceTypeFavorite:
+		return SourceTypeFavorite + ":" + ch.MediaID + ":" + ch.FavoriteOrder()
+	case SourceTypeSeason:
+		return SourceTypeSeason + ":" + ch.Mid + ":" + ch.SeasonID
+	case SourceTypeSeries:
+		return SourceTypeSeries + ":" + ch.Mid + ":" + ch.SeriesID
+	case SourceTypePopular:
+		return SourceTypePopular
+	case SourceTypeWeekly:
+		return SourceTypeWeekly + ":" + strconv.Itoa(ch.Number)
+	case SourceTypeRanking:
+		return SourceTypeRanking + ":" + strconv.Itoa(ch.Rid)
+	case SourceTypeSearch:
+		return SourceTypeSearch + ":" + ch.Keyword
+	case SourceTypeBangumi:
+		return SourceTypeBangumi + ":" + ch.SeasonID
+	case SourceTypeArticle:
+		return SourceTypeArticle + ":" + ch.Mid
+	case SourceTypeTimeline, SourceTypeWatchLater, SourceTypeHistory:
+		return ch.SourceType()
+	default:
+		return ch.Mid
+	}
+}
+
+// IsRanked 该来源是否自带排名顺序（热门、每周必看、排行榜）
+// 单独展示时应保留接口返回的顺序，而不是按发布时间重排
+func (ch ChannelInfo) IsRanked() bool {
+	switch ch.SourceType() {
+	case SourceTypePopular, SourceTypeWeekly, SourceTypeRanking:
+		return true
+	}
+	return false
+}
+
+// IsPersonal 该来源是否为登录用户的个人来源（关注时间线、稍后再看、历史记录）
+func (ch ChannelInfo) IsPersonal() bool {
+	switch ch.SourceType() {
+	case SourceTypeTimeline, SourceTypeWatchLater, SourceTypeHistory:
+		return true
+	}
+	return false
+}
+
+// FavoriteOrder 返回收藏夹排序方式，未配置时按收藏时间
+func (ch ChannelInfo) FavoriteOrder() string {
+	if ch.Order == FavoriteOrderPubdate {
+		return FavoriteOrderPubdate
+	}
+	return FavoriteOrderFavTime
+}
+
+// validate 校验单个来源配置
+func (ch ChannelInfo) validate() error {
+	switch ch.SourceType() {
+	case SourceTypeUploads:
+		if ch.Mid == "" {
+			return fmt.Errorf("缺少 mid")
+		}
+	case SourceTypeFavorite:
+		if ch.MediaID == "" {
+			return fmt.Errorf("收藏夹缺少 media_id")
+		}
+		if ch.Order != "" && ch.Order != FavoriteOrderFavTime && ch.Order != FavoriteOrderPubdate {
+			return fmt.Errorf("不支持的收藏夹排序方式: %s", ch.Order)
+		}
+	case SourceTypeSeason:
+		if ch.Mid == "" || ch.SeasonID == "" {
+			return fmt.Errorf("合集需要同时配置 mid 和 season_id")
+		}
+	case SourceTypeSeries:
+		if ch.Mid == "" || ch.SeriesID == "" {
+			return fmt.Errorf("系列需要同时配置 mid 和 series_id")
+		}
+	case SourceTypePopular:
+	case SourceTypeWeekly:
+		if ch.Number < 0 {
+			return fmt.Errorf("每周必看期数不能为负数")
+		}
+	case SourceTypeRanking:
+		if ch.Rid < 0 {
+			return fmt.Errorf("排行榜分区 ID 不能为负数")
+		}
+	case SourceTypeSearch:
+		if ch.Keyword == "" {
+			return fmt.Errorf("关键词搜索缺少 keyword")
+		}
+	case SourceTypeBangumi:
+		if ch.SeasonID == "" {
+			return fmt.Errorf("番剧缺少 season_id")
+		}
+	case SourceTypeArticle:
+		if ch.Mid == "" {
+			return fmt.Errorf("专栏缺少 mid")
+		}
+	case SourceTypeTimeline, SourceTypeWatchLater, SourceTypeHistory:
+	default:
+		return fmt.Errorf("不支持的来源类型: %s", ch.Type)
+	}
+
+	if ch.Limit < 0 {
+		return fmt.Errorf("limit 不能为负数")
+	}
+	return ch.FilterOptions.validate()
+}
+
+// Uploaders 返回所有启用的 UP 主投稿类型的配置（用于直播、动态等按 UP 主维度的功能）
+func (c *Config) Uploaders() []ChannelInfo {
+	result := make([]ChannelInfo, 0, len(c.Channels))
+	for _, ch := range c.Channels {
+		if ch.SourceType() == SourceTypeUploads && ch.IsEnabled() {
+			result = append(result, ch)
+		}
+	}
+	return result
+}
+
+// Articles 返回所有启用的专栏类型的配置
+func (c *Config) Articles() []ChannelInfo {
+	result := make([]ChannelInfo, 0, len(c.Channels))
+	for _, ch := range c.Channels {
+		if ch.SourceType() == SourceTypeArticle && ch.IsEnabled() {
+			result = append(result, ch)
+		}
+	}
+	return result
+}
+
+// Sources 返回参与汇总的全部来源：channels 中启用的条目，以及设置了 merge 的关键词订阅
+func (c *Config) Sources() []ChannelInfo {
+	result := make([]ChannelInfo, 0, len(c.Channels)+len(c.Searches))
+	for _, ch := range c.Channels {
+		if ch.IsEnabled() {
+			result = append(result, ch)
+		}
+	}
+	for _, si := range c.Searches {
+		if si.Merge {
+			result = append(result, si.Source())
+		}
+	}
+	return result
+}
+
+// TimelineSource 返回关注时间线来源，未配置任何来源但已登录时作为默认来源
+func TimelineSource() ChannelInfo {
+	return ChannelInfo{Name: "关注动态", Type: SourceTypeTimeline}
+}
+
+// FindSearch 按名称查找关键词订阅
+func (c *Config) FindSearch(name string) (SearchInfo, bool) {
+	for _, si := range c.Searches {
+		if si.Name == name {
+			return si, true
+		}
+	}
+	return SearchInfo{}, false
+}
+
+// FindGroup 按名称查找频道分组
+func (c *Config) FindGroup(name string) (GroupInfo, bool) {
+	for _, g := range c.Groups {
+		if g.Name == name {
+			return g, true
+		}
+	}
+	return GroupInfo{}, false
+}
+
+// GroupSources 返回分组内参与汇总的来源，按配置中的顺序排列
+// 停用的频道不参与汇总；关键词订阅无论是否设置 merge 都可以加入分组
+func (c *Config) GroupSources(g GroupInfo) []ChannelInfo {
+	members := make(map[string]bool, len(g.Channels))
+	for _, m := range g.Channels {
+		members[m] = true
+	}
+
+	result := make([]ChannelInfo, 0, len(g.Channels))
+	for _, ch := range c.Channels {
+		if ch.IsEnabled() && (members[ch.Name] || (ch.Mid != "" && members[ch.Mid])) {
+			result = append(result, ch)
+		}
+	}
+	for _, si := range c.Searches {
+		if members[si.Name] {
+			result = append(result, si.Source())
+		}
+	}
+	return result
+}
+
+// hasMember 判断分组成员引用是否能匹配到已配置的频道或关键词订阅
+func (c *Config) hasMember(ref string) bool {
+	for _, ch := range c.Channels {
+		if ch.Name == ref || (ch.Mid != "" && ch.Mid == ref) {
+			return true
+		}
+	}
+	_, ok := c.FindSearch(ref)
+	return ok
+}
+
+// FieldError 指向配置中某个字段或条目的校验错误
+type FieldError struct {
+	Path string // 字段路径，如 channels[2]、server.port
+	Name string // 条目名称（可选），便于在 JSON 配置中定位
+	Line int    // 所在行号，0 表示未知
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	loc := e.Path
+	if e.Name != "" {
+		loc += " (" + e.Name + ")"
+	}
+	if e.Line > 0 {
+		loc = fmt.Sprintf("第 %d 行 %s", e.Line, loc)
+	}
+	return loc + ": " + e.Err.Error()
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validate 校验配置是否合法
+func (c *Config) Validate() error {
+	if err := c.validateSettings(); err != nil {
+		return err
+	}
+
+	for i, ch := range c.Channels {
+		path := fmt.Sprintf("channels[%d]", i)
+		// 关键词搜索只能通过 searches 配置
+		if ch.SourceType() == SourceTypeSearch {
+			return &FieldError{Path: path, Name: ch.Name, Err: fmt.Errorf("关键词搜索请在 searches 中配置")}
+		}
+		if err := ch.validate(); err != nil {
+			return &FieldError{Path: path, Name: ch.Name, Err: err}
+		}
+	}
+
+	names := make(map[string]bool, len(c.Searches))
+	for i, si := range c.Searches {
+		path := fmt.Sprintf("searches[%d]", i)
+		if si.Name == "" {
+			return &FieldError{Path: path, Err: fmt.Errorf("缺少 name")}
+		}
+		if names[si.Name] {
+			return &FieldError{Path: path, Err: fmt.Errorf("名称重复: %s", si.Name)}
+		}
+		names[si.Name] = true
+		if err := si.Source().validate(); err != nil {
+			return &FieldError{Path: path, Name: si.Name, Err: err}
+		}
+	}
+
+	if err := c.validateGroups(); err != nil {
+		return err
+	}
+
+	return c.Pool.validate()
+}
+
+// validateGroups 校验频道分组，成员必须能匹配到已配置的频道或关键词订阅
+func (c *Config) validateGroups() error {
+	names := make(map[string]bool, len(c.Groups))
+	for i, g := range c.Groups {
+		path := fmt.Sprintf("groups[%d]", i)
+		if !groupNamePattern.MatchString(g.Name) {
+			return &FieldError{Path: path, Name: g.Name, Err: fmt.Errorf("名称只能包含字母、数字、- 与 _")}
+		}
+		if names[g.Name] {
+			return &FieldError{Path: path, Err: fmt.Errorf("名称重复: %s", g.Name)}
+		}
+		names[g.Name] = true
+
+		if len(g.Channels) == 0 {
+			return &FieldError{Path: path, Name: g.Name, Err: fmt.Errorf("缺少 channels")}
+		}
+		for j, ref := range g.Channels {
+			if !c.hasMember(ref) {
+				return &FieldError{
+					Path: fmt.Sprintf("%s.channels[%d]", path, j),
+					Name: g.Name,
+					Err:  fmt.Errorf("未找到频道或关键词订阅: %s", ref),
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validate 校验身份池配置
+func (p PoolConfig) validate() error {
+	switch p.Strategy {
+	case "", PoolStrategyRoundRobin, PoolStrategyLeastThrottled:
+	default:
+		return &FieldError{Path: "pool.strategy", Err: fmt.Errorf("未知的分配策略: %s", p.Strategy)}
+	}
+
+	// default 为主身份名称
+	names := map[string]bool{"default": true}
+	for i := range p.Identities {
+		name := p.IdentityName(i)
+		if names[name] {
+			return &FieldError{Path: fmt.Sprintf("pool.identities[%d]", i), Err: fmt.Errorf("名称重复: %s", name)}
+		}
+		names[name] = true
+	}
+	return nil
 }
 
 // DefaultConfig 返回默认配置
@@ -26,33 +531,27 @@ func DefaultConfig() *Config {
 	}
 }
 
-// Load 从文件加载配置
-func Load(path string) (*Config, error) {
-	// 如果路径为空，尝试默认路径
+// ResolvePath 返回实际使用的配置文件路径，为空时使用默认路径
+func ResolvePath(path string) string {
 	if path == "" {
-		path = getDefaultConfigPath()
-	}
-
-	// 检查文件是否存在
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return DefaultConfig(), nil
+		return getDefaultConfigPath()
 	}
+	return path
+}
 
-	// 读取文件
-	data, err := os.ReadFile(path)
+// Load 从文件加载配置，将 mid 解析为数字并校验
+func Load(path string) (*Config, error) {
+	f, err := LoadFile(path)
 	if err != nil {
-		return nil, fmt.Errorf("读取配置文件失败: %w", err)
-	}
-
-	// 解析 JSON
-	cfg := DefaultConfig()
-	if err := json.Unmarshal(data, cfg); err != nil {
-		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+		return nil, err
 	}
 
-	return cfg, nil
+	return f.resolve(true)
 }
 
+// defaultConfigNames 默认配置文件名，按顺序查找
+var defaultConfigNames = []string{"config.json", "config.yaml", "config.yml"}
+
 // getDefaultConfigPath 获取默认配置文件路径
 func getDefaultConfigPath() string {
 	// 1. 优先使用环境变量
@@ -62,16 +561,21 @@ func getDefaultConfigPath() string {
 
 	// 2. 检查当前工作目录
 	defaultName := filepath.Join("config", "config.json")
-	if _, err := os.Stat(defaultName); err == nil {
-		return defaultName
+	for _, name := range defaultConfigNames {
+		path := filepath.Join("config", name)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
 	}
 
 	// 3. 检查可执行文件所在目录
 	exe, err := os.Executable()
 	if err == nil {
-		path := filepath.Join(filepath.Dir(exe), "config", "config.json")
-		if _, err := os.Stat(path); err == nil {
-			return path
+		for _, name := range defaultConfigNames {
+			path := filepath.Join(filepath.Dir(exe), "config", name)
+			if _, err := os.Stat(path); err == nil {
+				return path
+			}
 		}
 	}
 
@@ -79,16 +583,49 @@ func getDefaultConfigPath() string {
 	return defaultName
 }
 
-// Save 保存配置到文件
+// Save 保存配置到文件，扩展名为 .yaml/.yml 时写入 YAML
+// 先写入同目录的临时文件再重命名，避免写入中途失败或配置监听读到不完整的文件
 func (c *Config) Save(path string) error {
-	data, err := json.MarshalIndent(c, "", "  ")
+	var data []byte
+	var err error
+	if isYAML(path) {
+		data, err = encodeYAML(c)
+	} else {
+		data, err = json.MarshalIndent(c, "", "  ")
+	}
 	if err != nil {
 		return fmt.Errorf("序列化配置失败: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("写入配置文件失败: %w", err)
 	}
 
 	return nil
 }
+
+// writeFileAtomic 通过临时文件与重命名原子地写入文件
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	// 重命名成功后临时文件已不存在，删除失败可忽略
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
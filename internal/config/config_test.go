@@ -0,0 +1,387 @@
+// Package config 配置单元测试
+package config
+
+import (
+	"testing"
+	"time"
+)
+
+// TestChannelInfo_CacheKey 测试不同来源的缓存键
+func TestChannelInfo_CacheKey(t *testing.T) {
+	tests := []struct {
+		name     string
+		channel  ChannelInfo
+		expected string
+	}{
+		{
+			name:     "默认为 UP 主投稿",
+			channel:  ChannelInfo{Mid: "946974"},
+			expected: "946974",
+		},
+		{
+			name:     "收藏夹默认按收藏时间",
+			channel:  ChannelInfo{Type: SourceTypeFavorite, MediaID: "123"},
+			expected: "favorite:123:fav_time",
+		},
+		{
+			name:     "收藏夹按发布时间",
+			channel:  ChannelInfo{Type: SourceTypeFavorite, MediaID: "123", Order: FavoriteOrderPubdate},
+			expected: "favorite:123:pubdate",
+		},
+		{
+			name:     "合集",
+			channel:  ChannelInfo{Type: SourceTypeSeason, Mid: "1", SeasonID: "2"},
+			expected: "season:1:2",
+		},
+		{
+			name:     "系列",
+			channel:  ChannelInfo{Type: SourceTypeSeries, Mid: "1", SeriesID: "3"},
+			expected: "series:1:3",
+		},
+		{
+			name:     "每周必看最新一期",
+			channel:  ChannelInfo{Type: SourceTypeWeekly},
+			expected: "weekly:0",
+		},
+		{
+			name:     "分区排行榜",
+			channel:  ChannelInfo{Type: SourceTypeRanking, Rid: 188},
+			expected: "ranking:188",
+		},
+		{
+			name:     "番剧",
+			channel:  ChannelInfo{Type: SourceTypeBangumi, SeasonID: "45969"},
+			expected: "bangumi:45969",
+		},
+		{
+			name:     "专栏",
+			channel:  ChannelInfo{Type: SourceTypeArticle, Mid: "946974"},
+			expected: "article:946974",
+		},
+		{
+			name:     "关注时间线",
+			channel:  TimelineSource(),
+			expected: "timeline",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.channel.CacheKey(); got != tt.expected {
+				t.Errorf("CacheKey() = %s, want %s", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestConfig_Validate 测试配置校验
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		channel ChannelInfo
+		wantErr bool
+	}{
+		{
+			name:    "合法的 UP 主",
+			channel: ChannelInfo{Mid: "946974"},
+		},
+		{
+			name:    "UP 主缺少 mid",
+			channel: ChannelInfo{Name: "无 mid"},
+			wantErr: true,
+		},
+		{
+			name:    "合法的收藏夹",
+			channel: ChannelInfo{Type: SourceTypeFavorite, MediaID: "123"},
+		},
+		{
+			name:    "收藏夹缺少 media_id",
+			channel: ChannelInfo{Type: SourceTypeFavorite},
+			wantErr: true,
+		},
+		{
+			name:    "收藏夹排序方式非法",
+			channel: ChannelInfo{Type: SourceTypeFavorite, MediaID: "123", Order: "views"},
+			wantErr: true,
+		},
+		{
+			name:    "合集缺少 mid",
+			channel: ChannelInfo{Type: SourceTypeSeason, SeasonID: "2"},
+			wantErr: true,
+		},
+		{
+			name:    "系列缺少 series_id",
+			channel: ChannelInfo{Type: SourceTypeSeries, Mid: "1"},
+			wantErr: true,
+		},
+		{
+			name:    "专栏缺少 mid",
+			channel: ChannelInfo{Type: SourceTypeArticle},
+			wantErr: true,
+		},
+		{
+			name:    "合法的关注时间线",
+			channel: ChannelInfo{Type: SourceTypeTimeline},
+		},
+		{
+			name:    "合法的稍后再看",
+			channel: ChannelInfo{Type: SourceTypeWatchLater},
+		},
+		{
+			name:    "未知来源类型",
+			channel: ChannelInfo{Type: "unknown", Mid: "1"},
+			wantErr: true,
+		},
+		{
+			name: "合法的过滤条件",
+			channel: ChannelInfo{Mid: "1", Limit: 5, FilterOptions: FilterOptions{
+				Exclude: []string{"直播回放|录播"}, MinDuration: Duration(time.Minute), MaxDuration: Duration(time.Hour),
+			}},
+		},
+		{
+			name:    "标题正则无效",
+			channel: ChannelInfo{Mid: "1", FilterOptions: FilterOptions{Include: []string{"(回放"}}},
+			wantErr: true,
+		},
+		{
+			name:    "最短时长大于最长时长",
+			channel: ChannelInfo{Mid: "1", FilterOptions: FilterOptions{MinDuration: Duration(time.Hour), MaxDuration: Duration(time.Minute)}},
+			wantErr: true,
+		},
+		{
+			name:    "数量限制为负数",
+			channel: ChannelInfo{Mid: "1", Limit: -1},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Channels: []ChannelInfo{tt.channel}}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestChannelInfo_IsRanked 测试排名类来源识别
+func TestChannelInfo_IsRanked(t *testing.T) {
+	ranked := []string{SourceTypePopular, SourceTypeWeekly, SourceTypeRanking}
+	for _, typ := range ranked {
+		if !(ChannelInfo{Type: typ}).IsRanked() {
+			t.Errorf("%s 应为排名类来源", typ)
+		}
+	}
+
+	if (ChannelInfo{Mid: "1"}).IsRanked() {
+		t.Error("UP 主投稿不应为排名类来源")
+	}
+}
+
+// TestConfig_Sources 测试关键词订阅的合并与停用来源的排除
+func TestConfig_Sources(t *testing.T) {
+	disabled := false
+	cfg := &Config{
+		Channels: []ChannelInfo{{Mid: "1"}, {Mid: "2", Enabled: &disabled}},
+		Searches: []SearchInfo{
+			{Name: "merged", Keyword: "a", Merge: true},
+			{Name: "alone", Keyword: "b"},
+		},
+	}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+
+	sources := cfg.Sources()
+	if len(sources) != 2 {
+		t.Fatalf("got %d sources, want 2 (停用的来源不参与汇总)", len(sources))
+	}
+	if len(cfg.Uploaders()) != 1 {
+		t.Errorf("Uploaders() 不应包含停用的 UP 主")
+	}
+	if sources[1].SourceType() != SourceTypeSearch || sources[1].Keyword != "a" {
+		t.Errorf("合并的关键词订阅不正确: %+v", sources[1])
+	}
+
+	if _, ok := cfg.FindSearch("alone"); !ok {
+		t.Error("FindSearch() 应能找到未合并的关键词订阅")
+	}
+
+	cfg.Searches = append(cfg.Searches, SearchInfo{Name: "alone", Keyword: "c"})
+	if err := cfg.Validate(); err == nil {
+		t.Error("重复名称应校验失败")
+	}
+}
+
+// TestConfig_Groups 测试频道分组的校验与成员匹配
+func TestConfig_Groups(t *testing.T) {
+	disabled := false
+	base := Config{
+		Channels: []ChannelInfo{{Mid: "1", Name: "科技 UP"}, {Mid: "2"}, {Mid: "3", Enabled: &disabled}},
+		Searches: []SearchInfo{{Name: "vision-pro", Keyword: "Vision Pro"}},
+	}
+
+	tests := []struct {
+		name    string
+		group   GroupInfo
+		wantErr bool
+		sources int
+	}{
+		{name: "按名称、mid 与关键词订阅匹配", group: GroupInfo{Name: "tech", Channels: []string{"科技 UP", "2", "vision-pro"}}, sources: 3},
+		{name: "停用的频道不参与汇总", group: GroupInfo{Name: "tech", Channels: []string{"3"}}, sources: 0},
+		{name: "名称包含非法字符", group: GroupInfo{Name: "科技/数码", Channels: []string{"2"}}, wantErr: true},
+		{name: "缺少成员", group: GroupInfo{Name: "tech"}, wantErr: true},
+		{name: "成员不存在", group: GroupInfo{Name: "tech", Channels: []string{"99"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			cfg.Groups = []GroupInfo{tt.group}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := len(cfg.GroupSources(tt.group)); got != tt.sources {
+				t.Errorf("GroupSources() 数量 = %d, want %d", got, tt.sources)
+			}
+		})
+	}
+
+	cfg := base
+	cfg.Groups = []GroupInfo{{Name: "tech", Channels: []string{"2"}}, {Name: "tech", Channels: []string{"1"}}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("重复的分组名称应校验失败")
+	}
+}
+
+// TestConfig_MergeChannels 测试关注列表合并
+func TestConfig_MergeChannels(t *testing.T) {
+	cfg := &Config{
+		Channels: []ChannelInfo{
+			{Mid: "1", Name: "自定义名称"},
+			{Mid: "2"},
+			{Mid: "3", Name: "已取关"},
+			{Type: SourceTypeFavorite, MediaID: "99", Name: "收藏夹"},
+		},
+	}
+	imported := []ChannelInfo{
+		{Mid: "1", Name: "接口名称"},
+		{Mid: "2", Name: "补全的名称"},
+		{Mid: "4", Name: "新 UP"},
+		{Mid: "4", Name: "重复"},
+	}
+
+	merged, diff := cfg.MergeChannels(imported)
+
+	if len(merged.Channels) != 5 {
+		t.Fatalf("len(Channels) = %d, want 5", len(merged.Channels))
+	}
+	if merged.Channels[0].Name != "自定义名称" {
+		t.Errorf("应保留自定义名称, got %s", merged.Channels[0].Name)
+	}
+	if merged.Channels[1].Name != "补全的名称" {
+		t.Errorf("应补全空名称, got %s", merged.Channels[1].Name)
+	}
+	if merged.Channels[4].Mid != "4" || merged.Channels[4].Name != "新 UP" {
+		t.Errorf("新增条目 = %+v", merged.Channels[4])
+	}
+	if cfg.Channels[1].Name != "" {
+		t.Errorf("不应修改原配置, got %s", cfg.Channels[1].Name)
+	}
+
+	if len(diff.Added) != 1 || len(diff.Named) != 1 || diff.Unchanged != 1 {
+		t.Errorf("diff = %+v", diff)
+	}
+	if len(diff.NotFollowed) != 1 || diff.NotFollowed[0].Mid != "3" {
+		t.Errorf("NotFollowed = %+v, want mid 3", diff.NotFollowed)
+	}
+	if !diff.HasChanges() {
+		t.Error("HasChanges() = false, want true")
+	}
+
+	// 再次合并不应产生变更
+	_, again := merged.MergeChannels(imported)
+	if again.HasChanges() {
+		t.Errorf("重复导入不应产生变更: %+v", again)
+	}
+}
+
+// TestConfig_LoginCredential 测试登录凭据的环境变量覆盖
+func TestConfig_LoginCredential(t *testing.T) {
+	cfg := &Config{Credential: CredentialInfo{SESSDATA: "file-sess", BiliJct: "file-jct"}}
+
+	t.Setenv("SESSDATA", "")
+	t.Setenv("BILI_JCT", "")
+	t.Setenv("DEDEUSERID", "")
+	if got := cfg.LoginCredential(); got != cfg.Credential {
+		t.Errorf("无环境变量时 LoginCredential() = %+v, want %+v", got, cfg.Credential)
+	}
+
+	t.Setenv("SESSDATA", "env-sess")
+	t.Setenv("DEDEUSERID", "42")
+	got := cfg.LoginCredential()
+	want := CredentialInfo{SESSDATA: "env-sess", BiliJct: "file-jct", DedeUserID: "42"}
+	if got != want {
+		t.Errorf("LoginCredential() = %+v, want %+v", got, want)
+	}
+	if cfg.Credential.SESSDATA != "file-sess" {
+		t.Error("环境变量不应修改配置")
+	}
+}
+
+// TestPoolConfig_Validate 测试身份池配置校验
+func TestPoolConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		pool    PoolConfig
+		wantErr bool
+	}{
+		{
+			name: "未配置身份池",
+		},
+		{
+			name: "合法的身份池",
+			pool: PoolConfig{
+				Strategy:   PoolStrategyLeastThrottled,
+				Identities: []IdentityInfo{{Name: "小号"}, {UserAgent: "Mozilla/5.0"}},
+			},
+		},
+		{
+			name:    "未知分配策略",
+			pool:    PoolConfig{Strategy: "random"},
+			wantErr: true,
+		},
+		{
+			name:    "身份名称重复",
+			pool:    PoolConfig{Identities: []IdentityInfo{{Name: "a"}, {Name: "a"}}},
+			wantErr: true,
+		},
+		{
+			name:    "与默认名称重复",
+			pool:    PoolConfig{Identities: []IdentityInfo{{}, {Name: "identity-1"}}},
+			wantErr: true,
+		},
+		{
+			name:    "与主身份名称重复",
+			pool:    PoolConfig{Identities: []IdentityInfo{{Name: "default"}}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &Config{Pool: tt.pool}
+			err := cfg.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
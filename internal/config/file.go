@@ -0,0 +1,308 @@
+// Package config 提供配置文件的原样修改
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// File 供管理接口与导入修改的配置文件
+// Config 为文件中的原始配置，mid 保留主页链接、短链接与 @名称等写法，写回时不会被替换为数字 mid；
+// YAML 文件写回时保留注释与未修改部分的原始写法
+type File struct {
+	Path   string
+	Config *Config
+	root   *yaml.Node // YAML 文档节点，JSON 文件或文件不存在时为 nil
+	data   []byte     // JSON 文件内容，用于将校验错误定位到行
+}
+
+// LoadFile 读取配置文件但不解析 mid、不做校验，文件不存在时返回默认配置
+func LoadFile(path string) (*File, error) {
+	path = ResolvePath(path)
+	f := &File{Path: path, Config: DefaultConfig()}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return f, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+
+	if isYAML(path) {
+		f.root, err = decodeYAML(data, f.Config)
+	} else {
+		f.data = data
+		err = decodeJSON(data, f.Config)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("解析配置文件失败: %w", err)
+	}
+	return f, nil
+}
+
+// Resolve 返回将 mid 解析为数字并通过校验的配置副本，原始配置保持不变
+// 副本中的频道与原始配置一一对应，下标一致
+func (f *File) Resolve() (*Config, error) {
+	return f.resolve(false)
+}
+
+// resolve 解析并校验配置，withLine 为 true 时为校验错误补充行号
+// 原始配置修改后节点与行号不再对应，此时不应补充行号
+func (f *File) resolve(withLine bool) (*Config, error) {
+	cfg := *f.Config
+	cfg.Channels = slices.Clone(f.Config.Channels)
+
+	// 将主页链接、短链接与 @名称解析为数字 mid
+	if err := cfg.resolveMids(f.Path); err != nil {
+		return nil, fmt.Errorf("解析 UP 主失败: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		var fieldErr *FieldError
+		if withLine && errors.As(err, &fieldErr) {
+			switch {
+			case f.root != nil:
+				fieldErr.Line = nodeLine(f.root, fieldErr.Path)
+			case f.data != nil:
+				fieldErr.Line = jsonLine(f.data, fieldErr.Path)
+			}
+		}
+		return nil, fmt.Errorf("配置校验失败: %w", err)
+	}
+	return &cfg, nil
+}
+
+// Save 将原始配置写回文件
+// JSON 文件整体重写；YAML 文件只替换修改过的节点，保留注释与未修改部分的写法
+func (f *File) Save() error {
+	if f.root == nil || f.root.Kind != yaml.DocumentNode || len(f.root.Content) == 0 {
+		return f.Config.Save(f.Path)
+	}
+
+	var updated yaml.Node
+	if err := updated.Encode(f.Config); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+	doc := *f.root
+	doc.Content = []*yaml.Node{mergeNode("", f.root.Content[0], &updated)}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return fmt.Errorf("序列化配置失败: %w", err)
+	}
+
+	if err := writeFileAtomic(f.Path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("写入配置文件失败: %w", err)
+	}
+	return nil
+}
+
+// mergeNode 以 updated 为准合并 YAML 节点：内容未变化的节点沿用 orig，保留注释与原始写法
+// 映射保留 orig 的键顺序，新增的键追加在末尾；序列中的元素按内容匹配 orig 中的元素，以适应删除与排序
+// key 为节点在所属映射中的键，序列元素与文档根节点为空
+func mergeNode(key string, orig, updated *yaml.Node) *yaml.Node {
+	if orig.Kind == updated.Kind {
+		switch updated.Kind {
+		case yaml.MappingNode:
+			return mergeMapping(orig, updated)
+		case yaml.SequenceNode:
+			return mergeSequence(orig, updated)
+		case yaml.ScalarNode:
+			if scalarEqual(key, orig, updated) {
+				return orig
+			}
+		}
+	}
+
+	// 值已变化，沿用原节点的注释
+	if updated.HeadComment == "" {
+		updated.HeadComment = orig.HeadComment
+	}
+	if updated.LineComment == "" {
+		updated.LineComment = orig.LineComment
+	}
+	if updated.FootComment == "" {
+		updated.FootComment = orig.FootComment
+	}
+	return updated
+}
+
+func mergeMapping(orig, updated *yaml.Node) *yaml.Node {
+	merged := *orig
+	merged.Content = make([]*yaml.Node, 0, len(updated.Content))
+	seen := make(map[string]bool, len(orig.Content)/2)
+
+	for i := 0; i+1 < len(orig.Content); i += 2 {
+		key, value := orig.Content[i], orig.Content[i+1]
+		seen[key.Value] = true
+		if newValue := mappingValue(updated, key.Value); newValue != nil {
+			merged.Content = append(merged.Content, key, mergeNode(key.Value, value, newValue))
+		} else if isZeroNode(value) {
+			// 显式写出的零值（如 limit: 0）与省略等价，原样保留
+			merged.Content = append(merged.Content, key, value)
+		}
+	}
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		if !seen[updated.Content[i].Value] {
+			merged.Content = append(merged.Content, updated.Content[i], updated.Content[i+1])
+		}
+	}
+	return &merged
+}
+
+func mergeSequence(orig, updated *yaml.Node) *yaml.Node {
+	merged := *orig
+	if len(orig.Content) == 0 {
+		// 原来为空列表（如 channels: []）时使用块格式
+		merged.Style = updated.Style
+	}
+	merged.Content = make([]*yaml.Node, len(updated.Content))
+	used := make([]bool, len(orig.Content))
+
+	// 先按内容匹配未修改的元素
+	for i, item := range updated.Content {
+		for j, o := range orig.Content {
+			if !used[j] && nodeEqual("", o, item) {
+				merged.Content[i] = o
+				used[j] = true
+				break
+			}
+		}
+	}
+
+	// 修改过的元素与最相似的原元素合并，沿用其注释与未修改字段的写法
+	for i, item := range updated.Content {
+		if merged.Content[i] != nil {
+			continue
+		}
+		best, bestScore := -1, 0
+		for j, o := range orig.Content {
+			if score := similarity(o, item); !used[j] && score > bestScore {
+				best, bestScore = j, score
+			}
+		}
+		if best < 0 {
+			merged.Content[i] = item
+			continue
+		}
+		merged.Content[i] = mergeNode("", orig.Content[best], item)
+		used[best] = true
+	}
+	return &merged
+}
+
+// similarity 返回 updated 映射中与 orig 取值相同的键数量，未超过半数时视为不相似，返回 0
+func similarity(orig, updated *yaml.Node) int {
+	if orig.Kind != yaml.MappingNode || updated.Kind != yaml.MappingNode {
+		return 0
+	}
+	same := 0
+	for i := 0; i+1 < len(updated.Content); i += 2 {
+		key := updated.Content[i].Value
+		if value := mappingValue(orig, key); value != nil && nodeEqual(key, value, updated.Content[i+1]) {
+			same++
+		}
+	}
+	if same*2 <= len(updated.Content)/2 {
+		return 0
+	}
+	return same
+}
+
+// nodeEqual 判断两个节点的内容是否等价，忽略注释、引号与显式写出的零值
+// key 为节点在所属映射中的键，用于识别时长字段
+func nodeEqual(key string, a, b *yaml.Node) bool {
+	if a.Kind != b.Kind {
+		return false
+	}
+
+	switch a.Kind {
+	case yaml.ScalarNode:
+		return scalarEqual(key, a, b)
+	case yaml.SequenceNode:
+		if len(a.Content) != len(b.Content) {
+			return false
+		}
+		for i := range a.Content {
+			if !nodeEqual("", a.Content[i], b.Content[i]) {
+				return false
+			}
+		}
+		return true
+	case yaml.MappingNode:
+		return mappingContains(a, b) && mappingContains(b, a)
+	}
+	return false
+}
+
+// mappingContains 判断 a 中的每个非零值键在 b 中都有等价的值
+func mappingContains(a, b *yaml.Node) bool {
+	for i := 0; i+1 < len(a.Content); i += 2 {
+		key, value := a.Content[i].Value, a.Content[i+1]
+		other := mappingValue(b, key)
+		if other == nil {
+			if !isZeroNode(value) {
+				return false
+			}
+			continue
+		}
+		if !nodeEqual(key, value, other) {
+			return false
+		}
+	}
+	return true
+}
+
+// durationKeys 类型为 Duration 的配置项，写回时 "5m" 与 "5m0s" 视为相同
+var durationKeys = map[string]bool{
+	"config_watch":    true,
+	"jitter_min":      true,
+	"jitter_max":      true,
+	"cookie_refresh":  true,
+	"profile_refresh": true,
+	"ttl":             true,
+	"min_duration":    true,
+	"max_duration":    true,
+}
+
+// scalarEqual 判断标量是否等价
+// 时长字段中写法不同的相同时长（如 "5m" 与 "5m0s"）视为相等，其余字段按原文比较
+func scalarEqual(key string, a, b *yaml.Node) bool {
+	if a.Value == b.Value {
+		return true
+	}
+	if !durationKeys[key] {
+		return false
+	}
+	da, errA := ParseDuration(a.Value)
+	db, errB := ParseDuration(b.Value)
+	return errA == nil && errB == nil && da == db
+}
+
+// isZeroNode 判断节点是否为零值（空值、0、空字符串、空列表或空映射）
+// false 不视为零值：enabled 省略时默认启用，与 false 含义相反
+func isZeroNode(n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		switch n.Value {
+		case "", "0", "null", "~":
+			return true
+		}
+		return false
+	case yaml.SequenceNode, yaml.MappingNode:
+		return len(n.Content) == 0
+	}
+	return false
+}
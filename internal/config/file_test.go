@@ -0,0 +1,158 @@
+// Package config 配置文件原样修改单元测试
+package config
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+// TestFile_SaveKeepsOriginal 测试修改后写回只替换修改过的部分：保留注释、未解析的 mid 与原始写法
+func TestFile_SaveKeepsOriginal(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `# glance-bilibili 配置
+cache:
+  ttl: 5m # 缓存时间
+channels:
+  # 科技区
+  - mid: https://space.bilibili.com/946974
+    name: 影视飓风
+    limit: 0
+  - mid: "2" # 待删除
+    name: 删除
+  - mid: "3"
+    name: 数码 # 保留的注释
+    max_duration: 10m
+`)
+
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	if f.Config.Channels[0].Mid != "https://space.bilibili.com/946974" {
+		t.Fatalf("原始配置不应解析 mid, got %s", f.Config.Channels[0].Mid)
+	}
+
+	resolved, err := f.Resolve()
+	if err != nil {
+		t.Fatalf("Resolve() error = %v", err)
+	}
+	if resolved.Channels[0].Mid != "946974" || f.Config.Channels[0].Mid != "https://space.bilibili.com/946974" {
+		t.Errorf("Resolve() 应只修改副本, resolved = %s, raw = %s", resolved.Channels[0].Mid, f.Config.Channels[0].Mid)
+	}
+
+	// 删除第二个频道，交换剩余顺序、修改移动的频道并追加一个
+	f.Config.Channels = []ChannelInfo{f.Config.Channels[2], f.Config.Channels[0], {Mid: "4", Name: "新增"}}
+	f.Config.Channels[0].Limit = 5
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	content := string(data)
+	for _, want := range []string{
+		"# glance-bilibili 配置",
+		"ttl: 5m # 缓存时间",
+		"# 科技区",
+		"mid: https://space.bilibili.com/946974",
+		"limit: 0",
+		"name: 数码 # 保留的注释",
+		"max_duration: 10m\n",
+		"limit: 5",
+		"name: 新增\n",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("写回内容缺少 %q:\n%s", want, content)
+		}
+	}
+	if strings.Contains(content, "删除") {
+		t.Errorf("已删除的频道及其注释不应保留:\n%s", content)
+	}
+	if strings.Index(content, "数码") > strings.Index(content, "影视飓风") {
+		t.Errorf("频道顺序未更新:\n%s", content)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if mids := []string{loaded.Channels[0].Mid, loaded.Channels[1].Mid, loaded.Channels[2].Mid}; strings.Join(mids, ",") != "3,946974,4" {
+		t.Errorf("重新加载的 mid = %v", mids)
+	}
+}
+
+// TestFile_SaveJSON 测试 JSON 配置写回时保留未解析的 mid
+func TestFile_SaveJSON(t *testing.T) {
+	path := writeConfig(t, "config.json", `{"channels": [{"mid": "https://space.bilibili.com/946974", "name": "影视飓风"}]}`)
+
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	f.Config.Channels = append(f.Config.Channels, ChannelInfo{Mid: "4", Name: "新增"})
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), `"mid": "https://space.bilibili.com/946974"`) {
+		t.Errorf("写回时不应替换为数字 mid:\n%s", data)
+	}
+}
+
+// TestFile_SaveReenable 测试停用后省略 enabled 写回时恢复启用
+func TestFile_SaveReenable(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "channels:\n  - mid: \"1\"\n    name: A\n  - mid: \"2\"\n    name: B\n")
+
+	disabled := false
+	edit := func(change func(channels []ChannelInfo)) {
+		t.Helper()
+		f, err := LoadFile(path)
+		if err != nil {
+			t.Fatalf("LoadFile() error = %v", err)
+		}
+		change(f.Config.Channels)
+		if err := f.Save(); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	edit(func(channels []ChannelInfo) { channels[0].Enabled = &disabled })
+	if data, _ := os.ReadFile(path); !strings.Contains(string(data), "enabled: false") {
+		t.Fatalf("停用后配置文件缺少 enabled: false:\n%s", data)
+	}
+
+	edit(func(channels []ChannelInfo) { channels[0] = ChannelInfo{Mid: "1", Name: "A"} })
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "enabled") {
+		t.Errorf("省略 enabled 后应恢复启用:\n%s", data)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.Channels[0].IsEnabled() || loaded.Channels[1].Mid != "2" {
+		t.Errorf("重新加载的频道 = %+v", loaded.Channels)
+	}
+}
+
+// TestFile_SaveScalar 测试仅时长字段按时长比较，其余字段按原文比较
+func TestFile_SaveScalar(t *testing.T) {
+	path := writeConfig(t, "config.yaml", "cache:\n  ttl: 60\nchannels:\n  - mid: \"1\"\n    name: \"60\"\n")
+
+	f, err := LoadFile(path)
+	if err != nil {
+		t.Fatalf("LoadFile() error = %v", err)
+	}
+	f.Config.Channels[0].Name = "1m"
+	if err := f.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "ttl: 60\n") {
+		t.Errorf("未修改的时长应保留原写法:\n%s", data)
+	}
+	if !strings.Contains(string(data), "name: 1m") {
+		t.Errorf("名称修改未写入:\n%s", data)
+	}
+}
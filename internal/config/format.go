@@ -0,0 +1,192 @@
+// Package config 提供 JSON 与 YAML 配置文件的解析
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// isYAML 根据扩展名判断配置文件是否为 YAML 格式
+func isYAML(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// decodeJSON 解析 JSON 配置，语法与类型错误附带行号
+func decodeJSON(data []byte, cfg *Config) error {
+	err := json.Unmarshal(data, cfg)
+	if err == nil {
+		return nil
+	}
+	if line := jsonErrorLine(data, err); line > 0 {
+		return fmt.Errorf("第 %d 行: %w", line, err)
+	}
+	return err
+}
+
+// jsonErrorLine 将 JSON 解析错误的字节偏移换算为行号，无法确定时返回 0
+func jsonErrorLine(data []byte, err error) int {
+	var offset int64
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.As(err, &syntaxErr):
+		offset = syntaxErr.Offset
+	case errors.As(err, &typeErr):
+		offset = typeErr.Offset
+	default:
+		return 0
+	}
+	if offset > int64(len(data)) {
+		offset = int64(len(data))
+	}
+	return bytes.Count(data[:offset], []byte("\n")) + 1
+}
+
+// jsonLine 按字段路径（如 channels[2]、server.port）查找 JSON 配置中的值所在行
+// 路径无法完全匹配时返回最近一级已找到的值所在行，找不到时返回 0
+func jsonLine(data []byte, path string) int {
+	offsets := make(map[string]int64)
+	if err := walkJSON(json.NewDecoder(bytes.NewReader(data)), data, "", offsets); err != nil {
+		return 0
+	}
+
+	for p := path; p != ""; p = parentPath(p) {
+		if offset, ok := offsets[p]; ok {
+			return bytes.Count(data[:offset], []byte("\n")) + 1
+		}
+	}
+	return 0
+}
+
+// walkJSON 遍历 JSON 值，记录每个字段路径对应值的起始偏移
+func walkJSON(dec *json.Decoder, data []byte, path string, offsets map[string]int64) error {
+	// 跳过上一个 token 之后的空白、逗号与冒号，定位到值的起始位置
+	offset := dec.InputOffset()
+	for offset < int64(len(data)) && strings.IndexByte(" \t\r\n,:", data[offset]) >= 0 {
+		offset++
+	}
+	offsets[path] = offset
+
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			child := fmt.Sprint(key)
+			if path != "" {
+				child = path + "." + child
+			}
+			if err := walkJSON(dec, data, child, offsets); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			if err := walkJSON(dec, data, fmt.Sprintf("%s[%d]", path, i), offsets); err != nil {
+				return err
+			}
+		}
+		_, err = dec.Token()
+	}
+	return err
+}
+
+// parentPath 返回字段路径的上一级，如 channels[2].mid 的上一级为 channels[2]
+func parentPath(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i > 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// decodeYAML 解析 YAML 配置，返回文档节点用于将校验错误定位到行
+// 未知字段视为错误，避免拼写错误被静默忽略
+func decodeYAML(data []byte, cfg *Config) (*yaml.Node, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return nil, err
+	}
+
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	return &root, nil
+}
+
+// nodeLine 按字段路径（如 channels[2]、server.port）查找 YAML 节点所在行
+// 路径无法完全匹配时返回最近一级已找到的节点所在行，找不到时返回 0
+func nodeLine(root *yaml.Node, path string) int {
+	node := root
+	if node.Kind == yaml.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+
+	line := 0
+	for _, part := range strings.Split(path, ".") {
+		key, rest, _ := strings.Cut(part, "[")
+		if node = mappingValue(node, key); node == nil {
+			return line
+		}
+		line = node.Line
+
+		for rest != "" {
+			idx, after, _ := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			if err != nil || node.Kind != yaml.SequenceNode || i >= len(node.Content) {
+				return line
+			}
+			node = node.Content[i]
+			line = node.Line
+			rest = strings.TrimPrefix(after, "[")
+		}
+	}
+	return line
+}
+
+// mappingValue 返回映射节点中 key 对应的值节点
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// encodeYAML 将配置序列化为 YAML
+func encodeYAML(cfg *Config) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(cfg); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,159 @@
+// Package config 配置文件格式单元测试
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeConfig 在临时目录写入配置文件并返回路径
+func writeConfig(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// TestLoad_YAML 测试加载 YAML 配置
+func TestLoad_YAML(t *testing.T) {
+	path := writeConfig(t, "config.yaml", `
+server:
+  port: 9000
+fetch:
+  workers: 2
+  jitter_max: 2s
+cache:
+  ttl: 10m
+render:
+  collapse_after: 3
+channels:
+  - mid: "946974"
+    name: 影视飓风
+  - type: favorite
+    media_id: "123"
+pool:
+  identities:
+    - name: alt
+      sessdata: xxx
+`)
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Server.Port != 9000 || cfg.Fetch.Workers != 2 || cfg.Render.CollapseAfter != 3 {
+		t.Errorf("全局设置 = %+v %+v %+v", cfg.Server, cfg.Fetch, cfg.Render)
+	}
+	if cfg.Fetch.JitterMax.Std() != 2*time.Second || cfg.Cache.TTL.Std() != 10*time.Minute {
+		t.Errorf("时长 = %s %s", cfg.Fetch.JitterMax, cfg.Cache.TTL)
+	}
+	if len(cfg.Channels) != 2 || cfg.Channels[1].MediaID != "123" {
+		t.Errorf("channels = %+v", cfg.Channels)
+	}
+	if len(cfg.Pool.Identities) != 1 || cfg.Pool.Identities[0].SESSDATA != "xxx" {
+		t.Errorf("pool = %+v", cfg.Pool)
+	}
+}
+
+// TestLoad_ErrorLine 测试解析与校验错误指向所在行
+func TestLoad_ErrorLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string
+		content  string
+		wantLine string
+	}{
+		{
+			name:     "YAML 条目校验失败",
+			file:     "config.yaml",
+			content:  "channels:\n  - mid: \"1\"\n  - type: favorite\n    name: 收藏夹\n",
+			wantLine: "第 3 行 channels[1] (收藏夹)",
+		},
+		{
+			name:     "YAML 字段校验失败",
+			file:     "config.yml",
+			content:  "channels: []\nrender:\n  limit: 10\n  collapse_after: -1\n",
+			wantLine: "第 4 行 render.collapse_after",
+		},
+		{
+			name:     "YAML 未知字段",
+			file:     "config.yaml",
+			content:  "channels: []\nserver:\n  prot: 80\n",
+			wantLine: "line 3",
+		},
+		{
+			name:     "YAML 时长格式错误",
+			file:     "config.yaml",
+			content:  "cache:\n  ttl: soon\n",
+			wantLine: "第 2 行",
+		},
+		{
+			name:     "JSON 条目校验失败",
+			file:     "config.json",
+			content:  "{\n  \"channels\": [\n    {\"mid\": \"1\"},\n    {\"type\": \"favorite\", \"name\": \"收藏夹\"}\n  ]\n}\n",
+			wantLine: "第 4 行 channels[1] (收藏夹)",
+		},
+		{
+			name:     "JSON 字段校验失败",
+			file:     "config.json",
+			content:  "{\n  \"channels\": [],\n  \"render\": {\n    \"limit\": 10,\n    \"collapse_after\": -1\n  }\n}\n",
+			wantLine: "第 5 行 render.collapse_after",
+		},
+		{
+			name:     "JSON 语法错误",
+			file:     "config.json",
+			content:  "{\n  \"channels\": [\n    {\"mid\": \"1\",}\n  ]\n}\n",
+			wantLine: "第 3 行",
+		},
+		{
+			name:     "JSON 类型错误",
+			file:     "config.json",
+			content:  "{\n  \"server\": {\n    \"port\": \"80\"\n  }\n}\n",
+			wantLine: "第 3 行",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Load(writeConfig(t, tt.file, tt.content))
+			if err == nil {
+				t.Fatal("Load() 应返回错误")
+			}
+			if !strings.Contains(err.Error(), tt.wantLine) {
+				t.Errorf("Load() error = %v, 应包含 %q", err, tt.wantLine)
+			}
+		})
+	}
+}
+
+// TestSave_YAML 测试 YAML 配置写回后可重新加载
+func TestSave_YAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	cfg := &Config{
+		Cache:    CacheConfig{TTL: Duration(90 * time.Second)},
+		Channels: []ChannelInfo{{Mid: "1", Name: "测试"}},
+		Pool:     PoolConfig{Identities: []IdentityInfo{{Name: "alt", CredentialInfo: CredentialInfo{SESSDATA: "s"}}}},
+	}
+	if err := cfg.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	if strings.Contains(string(data), "{") || !strings.Contains(string(data), "ttl: 1m30s") {
+		t.Errorf("应写入 YAML 格式:\n%s", data)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.Cache.TTL != cfg.Cache.TTL || !reflect.DeepEqual(loaded.Channels, cfg.Channels) || loaded.Pool.Identities[0] != cfg.Pool.Identities[0] {
+		t.Errorf("重新加载结果 = %+v", loaded)
+	}
+}
@@ -0,0 +1,86 @@
+// Package config 提供从关注列表导入 UP 主的合并逻辑
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ImportDiff 导入关注列表时的配置变更
+type ImportDiff struct {
+	Added       []ChannelInfo `json:"added"`        // 新增的 UP 主
+	Named       []ChannelInfo `json:"named"`        // 原先未设置名称、导入时补全名称的 UP 主
+	Unchanged   int           `json:"unchanged"`    // 已存在且保持不变的 UP 主数量
+	NotFollowed []ChannelInfo `json:"not_followed"` // 已配置但不在关注列表中的 UP 主（保留不删除）
+}
+
+// HasChanges 是否有需要写入的变更
+func (d ImportDiff) HasChanges() bool {
+	return len(d.Added) > 0 || len(d.Named) > 0
+}
+
+// String 以类似 diff 的格式输出变更
+func (d ImportDiff) String() string {
+	var b strings.Builder
+	for _, ch := range d.Added {
+		fmt.Fprintf(&b, "+ %s (mid: %s)\n", ch.Name, ch.Mid)
+	}
+	for _, ch := range d.Named {
+		fmt.Fprintf(&b, "~ %s (mid: %s) 补全名称\n", ch.Name, ch.Mid)
+	}
+	for _, ch := range d.NotFollowed {
+		fmt.Fprintf(&b, "? %s (mid: %s) 未在关注列表中，保留\n", ch.Name, ch.Mid)
+	}
+	fmt.Fprintf(&b, "新增 %d 个，补全名称 %d 个，未变化 %d 个，未关注 %d 个\n",
+		len(d.Added), len(d.Named), d.Unchanged, len(d.NotFollowed))
+	return b.String()
+}
+
+// MergeChannels 将导入的 UP 主合并进配置，返回合并后的新配置与变更
+// 已存在的 UP 主保留自定义名称，仅在名称为空时补全；不在导入列表中的 UP 主不会被删除
+func (c *Config) MergeChannels(imported []ChannelInfo) (*Config, ImportDiff) {
+	// 除 channels 外的配置原样保留
+	merged := *c
+	merged.Channels = make([]ChannelInfo, len(c.Channels), len(c.Channels)+len(imported))
+	copy(merged.Channels, c.Channels)
+
+	// 已配置的 UP 主投稿来源，按 mid 索引
+	existing := make(map[string]int, len(merged.Channels))
+	for i, ch := range merged.Channels {
+		if ch.SourceType() == SourceTypeUploads {
+			existing[ch.Mid] = i
+		}
+	}
+
+	var diff ImportDiff
+	seen := make(map[string]bool, len(imported))
+	for _, ch := range imported {
+		if ch.Mid == "" || seen[ch.Mid] {
+			continue
+		}
+		seen[ch.Mid] = true
+
+		i, ok := existing[ch.Mid]
+		if !ok {
+			added := ChannelInfo{Mid: ch.Mid, Name: ch.Name}
+			merged.Channels = append(merged.Channels, added)
+			diff.Added = append(diff.Added, added)
+			continue
+		}
+
+		if merged.Channels[i].Name == "" && ch.Name != "" {
+			merged.Channels[i].Name = ch.Name
+			diff.Named = append(diff.Named, merged.Channels[i])
+			continue
+		}
+		diff.Unchanged++
+	}
+
+	for _, ch := range c.Channels {
+		if ch.SourceType() == SourceTypeUploads && !seen[ch.Mid] {
+			diff.NotFollowed = append(diff.NotFollowed, ch)
+		}
+	}
+
+	return &merged, diff
+}
@@ -0,0 +1,230 @@
+// Package config 提供 UP 主引用（主页链接、短链接、@名称）解析
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"glance-bilibili/internal/logger"
+)
+
+// midCacheFile 解析结果缓存文件名，与配置文件位于同一目录
+const midCacheFile = "mid-cache.json"
+
+// UserMatch 按名称搜索到的用户
+type UserMatch struct {
+	Mid  string
+	Name string
+}
+
+// MidResolver 解析需要联网的 UP 主引用，由上层基于 Bilibili 接口实现
+type MidResolver interface {
+	// ExpandShortLink 展开 b23.tv 短链接，返回跳转后的完整链接
+	ExpandShortLink(link string) (string, error)
+	// SearchUsers 按名称搜索用户
+	SearchUsers(name string) ([]UserMatch, error)
+}
+
+// midResolver 加载配置时使用的解析器，未设置时仅支持主页链接
+var midResolver MidResolver
+
+// SetMidResolver 设置加载配置时使用的解析器
+func SetMidResolver(r MidResolver) {
+	midResolver = r
+}
+
+var (
+	// numericMidPattern 纯数字 mid
+	numericMidPattern = regexp.MustCompile(`^\d+$`)
+	// spaceURLPattern 匹配 space.bilibili.com/<mid> 与 m.bilibili.com/space/<mid>
+	spaceURLPattern = regexp.MustCompile(`(?:space\.bilibili\.com/|bilibili\.com/space/)(\d+)`)
+	// shortLinkPattern 匹配 b23.tv 短链接
+	shortLinkPattern = regexp.MustCompile(`^(?:https?://)?(?:www\.)?b23\.tv/\S+$`)
+)
+
+// errNoResolver 需要联网解析但未设置解析器
+var errNoResolver = errors.New("未设置解析器，无法解析短链接或 @名称")
+
+// resolvedMid 解析结果缓存条目
+type resolvedMid struct {
+	Mid  string `json:"mid"`
+	Name string `json:"name,omitempty"`
+}
+
+// parseSpaceURL 从 UP 主主页链接中提取 mid
+func parseSpaceURL(ref string) (string, bool) {
+	m := spaceURLPattern.FindStringSubmatch(ref)
+	if m == nil {
+		return "", false
+	}
+	return m[1], true
+}
+
+// resolveMidRef 将单个 UP 主引用解析为 mid，返回 mid 与（按名称解析时）匹配到的名称
+// 支持纯数字 mid、主页链接、b23.tv 短链接与 @名称
+func resolveMidRef(ref string, r MidResolver) (resolvedMid, error) {
+	ref = strings.TrimSpace(ref)
+
+	if numericMidPattern.MatchString(ref) {
+		return resolvedMid{Mid: ref}, nil
+	}
+
+	if mid, ok := parseSpaceURL(ref); ok {
+		return resolvedMid{Mid: mid}, nil
+	}
+
+	if shortLinkPattern.MatchString(ref) {
+		if r == nil {
+			return resolvedMid{}, errNoResolver
+		}
+		link := ref
+		if !strings.HasPrefix(link, "http") {
+			link = "https://" + link
+		}
+		expanded, err := r.ExpandShortLink(link)
+		if err != nil {
+			return resolvedMid{}, fmt.Errorf("展开短链接失败: %w", err)
+		}
+		mid, ok := parseSpaceURL(expanded)
+		if !ok {
+			return resolvedMid{}, fmt.Errorf("短链接 %s 指向的不是 UP 主主页: %s", ref, expanded)
+		}
+		return resolvedMid{Mid: mid}, nil
+	}
+
+	if name, ok := strings.CutPrefix(ref, "@"); ok && name != "" {
+		if r == nil {
+			return resolvedMid{}, errNoResolver
+		}
+		matches, err := r.SearchUsers(name)
+		if err != nil {
+			return resolvedMid{}, fmt.Errorf("搜索用户失败: %w", err)
+		}
+		return pickUserMatch(name, matches)
+	}
+
+	return resolvedMid{}, fmt.Errorf("无法识别的 mid: %s（支持数字 mid、主页链接、b23.tv 短链接或 @名称）", ref)
+}
+
+// ResolveMid 将单个 UP 主引用（数字 mid、主页链接、b23.tv 短链接或 @名称）解析为数字 mid
+// 按名称解析时同时返回匹配到的名称
+func ResolveMid(ref string) (mid string, name string, err error) {
+	resolved, err := resolveMidRef(ref, midResolver)
+	if err != nil {
+		return "", "", err
+	}
+	return resolved.Mid, resolved.Name, nil
+}
+
+// pickUserMatch 从搜索结果中选出与名称完全一致的唯一用户，否则返回包含候选项的错误
+func pickUserMatch(name string, matches []UserMatch) (resolvedMid, error) {
+	var exact []UserMatch
+	for _, m := range matches {
+		if strings.EqualFold(m.Name, name) {
+			exact = append(exact, m)
+		}
+	}
+
+	switch len(exact) {
+	case 1:
+		return resolvedMid{Mid: exact[0].Mid, Name: exact[0].Name}, nil
+	case 0:
+		if len(matches) == 0 {
+			return resolvedMid{}, fmt.Errorf("未找到名为 %s 的 UP 主", name)
+		}
+		return resolvedMid{}, fmt.Errorf("未找到名为 %s 的 UP 主，相近结果: %s，请改为填写 mid 或主页链接",
+			name, formatUserMatches(matches, 5))
+	default:
+		return resolvedMid{}, fmt.Errorf("名为 %s 的 UP 主不唯一: %s，请改为填写 mid 或主页链接",
+			name, formatUserMatches(exact, 5))
+	}
+}
+
+// formatUserMatches 格式化候选用户列表，最多显示 n 个
+func formatUserMatches(matches []UserMatch, n int) string {
+	parts := make([]string, 0, n)
+	for i, m := range matches {
+		if i >= n {
+			parts = append(parts, fmt.Sprintf("等 %d 个", len(matches)))
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%s (mid: %s)", m.Name, m.Mid))
+	}
+	return strings.Join(parts, "、")
+}
+
+// resolveMids 将 channels 中非数字的 mid 解析为数字 mid
+// 联网解析的结果缓存在配置文件同目录的 mid-cache.json 中，避免每次启动重复请求
+func (c *Config) resolveMids(configPath string) error {
+	cachePath := filepath.Join(filepath.Dir(configPath), midCacheFile)
+	var cache map[string]resolvedMid
+	dirty := false
+
+	for i := range c.Channels {
+		ch := &c.Channels[i]
+		if ch.Mid == "" || numericMidPattern.MatchString(ch.Mid) {
+			continue
+		}
+
+		if cache == nil {
+			cache = loadMidCache(cachePath)
+		}
+
+		ref := strings.TrimSpace(ch.Mid)
+		resolved, ok := cache[ref]
+		if !ok {
+			var err error
+			resolved, err = resolveMidRef(ref, midResolver)
+			if err != nil {
+				return fmt.Errorf("channels[%d] (%s): %w", i, ch.Name, err)
+			}
+			// 主页链接无需联网，不写入缓存
+			if _, isURL := parseSpaceURL(ref); !isURL {
+				cache[ref] = resolved
+				dirty = true
+			}
+		}
+
+		ch.Mid = resolved.Mid
+		if ch.Name == "" {
+			ch.Name = resolved.Name
+		}
+	}
+
+	if dirty {
+		saveMidCache(cachePath, cache)
+	}
+	return nil
+}
+
+// loadMidCache 读取解析结果缓存，文件不存在或损坏时返回空缓存
+func loadMidCache(path string) map[string]resolvedMid {
+	cache := make(map[string]resolvedMid)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cache
+	}
+	if err := json.Unmarshal(data, &cache); err != nil {
+		return make(map[string]resolvedMid)
+	}
+	return cache
+}
+
+// saveMidCache 写入解析结果缓存，失败时仅记录日志（下次加载会重新解析）
+func saveMidCache(path string, cache map[string]resolvedMid) {
+	data, err := json.MarshalIndent(cache, "", "  ")
+	if err == nil {
+		err = writeFileAtomic(path, data, 0644)
+	}
+	if err != nil {
+		logger.Warnw("写入 mid 解析缓存失败",
+			"path", path,
+			"error", err,
+		)
+	}
+}
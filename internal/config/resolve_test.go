@@ -0,0 +1,135 @@
+// Package config UP 主引用解析单元测试
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// fakeResolver 测试用解析器，记录调用次数
+type fakeResolver struct {
+	links map[string]string
+	users map[string][]UserMatch
+	calls int
+}
+
+func (f *fakeResolver) ExpandShortLink(link string) (string, error) {
+	f.calls++
+	if u, ok := f.links[link]; ok {
+		return u, nil
+	}
+	return "", errors.New("not found")
+}
+
+func (f *fakeResolver) SearchUsers(name string) ([]UserMatch, error) {
+	f.calls++
+	return f.users[name], nil
+}
+
+// TestResolveMidRef 测试单个 UP 主引用解析
+func TestResolveMidRef(t *testing.T) {
+	r := &fakeResolver{
+		links: map[string]string{
+			"https://b23.tv/abc123": "https://m.bilibili.com/space/946974?share_source=copy",
+			"https://b23.tv/video1": "https://www.bilibili.com/video/BV1xx411c7mD",
+		},
+		users: map[string][]UserMatch{
+			"影视飓风": {{Mid: "946974", Name: "影视飓风"}, {Mid: "1", Name: "影视飓风粉丝团"}},
+			"重名":   {{Mid: "1", Name: "重名"}, {Mid: "2", Name: "重名"}},
+			"模糊":   {{Mid: "3", Name: "模糊匹配"}},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		wantMid string
+		wantErr string
+	}{
+		{name: "数字 mid", ref: "946974", wantMid: "946974"},
+		{name: "主页链接", ref: "https://space.bilibili.com/946974?spm_id_from=333", wantMid: "946974"},
+		{name: "移动端主页链接", ref: "https://m.bilibili.com/space/946974", wantMid: "946974"},
+		{name: "短链接", ref: "https://b23.tv/abc123", wantMid: "946974"},
+		{name: "省略协议的短链接", ref: "b23.tv/abc123", wantMid: "946974"},
+		{name: "短链接指向视频", ref: "https://b23.tv/video1", wantErr: "不是 UP 主主页"},
+		{name: "按名称精确匹配", ref: "@影视飓风", wantMid: "946974"},
+		{name: "名称不唯一", ref: "@重名", wantErr: "不唯一"},
+		{name: "无完全匹配", ref: "@模糊", wantErr: "相近结果"},
+		{name: "未找到", ref: "@不存在", wantErr: "未找到"},
+		{name: "无法识别", ref: "abc", wantErr: "无法识别"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := resolveMidRef(tt.ref, r)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("resolveMidRef(%q) error = %v, want containing %q", tt.ref, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveMidRef(%q) error = %v", tt.ref, err)
+			}
+			if got.Mid != tt.wantMid {
+				t.Errorf("resolveMidRef(%q) = %s, want %s", tt.ref, got.Mid, tt.wantMid)
+			}
+		})
+	}
+
+	if _, err := resolveMidRef("@影视飓风", nil); !errors.Is(err, errNoResolver) {
+		t.Errorf("未设置解析器时 error = %v, want errNoResolver", err)
+	}
+}
+
+// TestConfig_ResolveMids 测试加载时解析并缓存
+func TestConfig_ResolveMids(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+
+	r := &fakeResolver{
+		users: map[string][]UserMatch{"影视飓风": {{Mid: "946974", Name: "影视飓风"}}},
+	}
+	SetMidResolver(r)
+	defer SetMidResolver(nil)
+
+	newConfig := func() *Config {
+		return &Config{Channels: []ChannelInfo{
+			{Mid: "@影视飓风"},
+			{Mid: "https://space.bilibili.com/25876945", Name: "极客湾"},
+			{Type: SourceTypeFavorite, MediaID: "1"},
+		}}
+	}
+
+	cfg := newConfig()
+	if err := cfg.resolveMids(configPath); err != nil {
+		t.Fatalf("resolveMids() error = %v", err)
+	}
+	if cfg.Channels[0].Mid != "946974" || cfg.Channels[0].Name != "影视飓风" {
+		t.Errorf("Channels[0] = %+v", cfg.Channels[0])
+	}
+	if cfg.Channels[1].Mid != "25876945" || cfg.Channels[1].Name != "极客湾" {
+		t.Errorf("Channels[1] = %+v", cfg.Channels[1])
+	}
+	if r.calls != 1 {
+		t.Errorf("calls = %d, want 1", r.calls)
+	}
+	if _, err := os.Stat(filepath.Join(dir, midCacheFile)); err != nil {
+		t.Errorf("缓存文件未写入: %v", err)
+	}
+
+	// 第二次加载命中缓存，不再联网
+	cfg = newConfig()
+	if err := cfg.resolveMids(configPath); err != nil {
+		t.Fatalf("resolveMids() error = %v", err)
+	}
+	if cfg.Channels[0].Mid != "946974" {
+		t.Errorf("Channels[0].Mid = %s, want 946974", cfg.Channels[0].Mid)
+	}
+	if r.calls != 1 {
+		t.Errorf("calls = %d, want 1 (应命中缓存)", r.calls)
+	}
+}
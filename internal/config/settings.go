@@ -0,0 +1,265 @@
+// Package config 提供全局设置
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// 全局设置默认值
+const (
+	DefaultPort           = 8082
+	DefaultLimit          = 25
+	DefaultCacheTTL       = 5 * time.Minute
+	DefaultWorkers        = 4
+	DefaultJitterMin      = 250 * time.Millisecond
+	DefaultJitterMax      = 1200 * time.Millisecond
+	DefaultCookieRefresh  = 12 * time.Hour
+	DefaultProfileRefresh = 6 * time.Hour
+	DefaultCollapseRows   = 4
+	DefaultConfigWatch    = 10 * time.Second
+)
+
+// ServerConfig HTTP 服务设置
+type ServerConfig struct {
+	Port        int      `json:"port,omitempty" yaml:"port,omitempty"`                 // HTTP 服务端口，默认 8082
+	AdminToken  string   `json:"admin_token,omitempty" yaml:"admin_token,omitempty"`   // 管理接口令牌，为空时关闭管理接口
+	ConfigWatch Duration `json:"config_watch,omitempty" yaml:"config_watch,omitempty"` // 配置文件变化检查间隔，默认 10s，负数为禁用
+}
+
+// FetchConfig 抓取设置
+type FetchConfig struct {
+	Workers        int      `json:"workers,omitempty" yaml:"workers,omitempty"`                 // 并发抓取数，默认 4
+	JitterMin      Duration `json:"jitter_min,omitempty" yaml:"jitter_min,omitempty"`           // 请求间随机延迟下限，默认 250ms
+	JitterMax      Duration `json:"jitter_max,omitempty" yaml:"jitter_max,omitempty"`           // 请求间随机延迟上限，默认 1.2s
+	CookieRefresh  Duration `json:"cookie_refresh,omitempty" yaml:"cookie_refresh,omitempty"`   // 登录 Cookie 刷新检查间隔，默认 12h，负数为禁用
+	ProfileRefresh Duration `json:"profile_refresh,omitempty" yaml:"profile_refresh,omitempty"` // 频道资料后台刷新间隔，默认 6h，负数为禁用
+}
+
+// CacheConfig 缓存设置
+type CacheConfig struct {
+	TTL Duration `json:"ttl,omitempty" yaml:"ttl,omitempty"` // 默认缓存时间，默认 5m，负数为禁用缓存，可通过 ?cache= 按请求覆盖
+}
+
+// RenderConfig 页面渲染设置
+type RenderConfig struct {
+	Limit             int `json:"limit,omitempty" yaml:"limit,omitempty"`                             // 默认显示数量，默认 25
+	CollapseAfter     int `json:"collapse_after,omitempty" yaml:"collapse_after,omitempty"`           // 默认折叠数量，为 0 时使用各页面自己的默认值
+	CollapseAfterRows int `json:"collapse_after_rows,omitempty" yaml:"collapse_after_rows,omitempty"` // grid 布局默认折叠行数，默认 4
+}
+
+// Settings 生效的全局设置
+// 优先级: 命令行参数 > 环境变量 > 配置文件 > 默认值
+// 时长类设置在各处含义一致：0 或未设置使用默认值，负数为禁用
+type Settings struct {
+	Server ServerConfig
+	Fetch  FetchConfig
+	Cache  CacheConfig
+	Render RenderConfig
+}
+
+// Settings 在配置文件的基础上应用环境变量与默认值，返回生效的全局设置
+// 命令行参数由调用方在返回值上覆盖；环境变量不会写回配置文件
+func (c *Config) Settings() (Settings, error) {
+	s := Settings{Server: c.Server, Fetch: c.Fetch, Cache: c.Cache, Render: c.Render}
+
+	if err := s.applyEnv(); err != nil {
+		return Settings{}, err
+	}
+	s.ApplyDefaults()
+	return s, nil
+}
+
+// ApplyDefaults 将为 0 的设置替换为默认值
+// 命令行参数覆盖后需再次调用，使 0 在命令行参数中同样表示默认值
+func (s *Settings) ApplyDefaults() {
+	if s.Server.Port == 0 {
+		s.Server.Port = DefaultPort
+	}
+	if s.Server.ConfigWatch == 0 {
+		s.Server.ConfigWatch = Duration(DefaultConfigWatch)
+	}
+	if s.Fetch.Workers == 0 {
+		s.Fetch.Workers = DefaultWorkers
+	}
+	if s.Fetch.JitterMin == 0 {
+		s.Fetch.JitterMin = Duration(DefaultJitterMin)
+	}
+	if s.Fetch.JitterMax == 0 {
+		s.Fetch.JitterMax = max(Duration(DefaultJitterMax), s.Fetch.JitterMin)
+	}
+	if s.Fetch.CookieRefresh == 0 {
+		s.Fetch.CookieRefresh = Duration(DefaultCookieRefresh)
+	}
+	if s.Fetch.ProfileRefresh == 0 {
+		s.Fetch.ProfileRefresh = Duration(DefaultProfileRefresh)
+	}
+	if s.Cache.TTL == 0 {
+		s.Cache.TTL = Duration(DefaultCacheTTL)
+	}
+	if s.Render.Limit == 0 {
+		s.Render.Limit = DefaultLimit
+	}
+	if s.Render.CollapseAfterRows == 0 {
+		s.Render.CollapseAfterRows = DefaultCollapseRows
+	}
+}
+
+// applyEnv 应用环境变量覆盖
+// 支持 PORT、ADMIN_TOKEN、DEFAULT_LIMIT、CACHE_TTL、FETCH_WORKERS
+func (s *Settings) applyEnv() error {
+	if v := os.Getenv("ADMIN_TOKEN"); v != "" {
+		s.Server.AdminToken = v
+	}
+
+	ints := []struct {
+		env    string
+		target *int
+	}{
+		{"PORT", &s.Server.Port},
+		{"DEFAULT_LIMIT", &s.Render.Limit},
+		{"FETCH_WORKERS", &s.Fetch.Workers},
+	}
+	for _, e := range ints {
+		v := os.Getenv(e.env)
+		if v == "" {
+			continue
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("环境变量 %s 不是正整数: %s", e.env, v)
+		}
+		*e.target = n
+	}
+
+	if v := os.Getenv("CACHE_TTL"); v != "" {
+		d, err := ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("环境变量 CACHE_TTL 不是合法的时长: %s", v)
+		}
+		s.Cache.TTL = d
+	}
+	return nil
+}
+
+// Validate 校验生效的全局设置（含命令行参数覆盖后的值）
+func (s Settings) Validate() error {
+	if s.Server.Port <= 0 || s.Server.Port > 65535 {
+		return fmt.Errorf("端口超出范围: %d", s.Server.Port)
+	}
+	if s.Fetch.Workers <= 0 {
+		return fmt.Errorf("并发抓取数必须大于 0: %d", s.Fetch.Workers)
+	}
+	if s.Fetch.JitterMin > s.Fetch.JitterMax {
+		return fmt.Errorf("请求延迟下限 %s 大于上限 %s", s.Fetch.JitterMin, s.Fetch.JitterMax)
+	}
+	if s.Render.Limit <= 0 {
+		return fmt.Errorf("默认显示数量必须大于 0: %d", s.Render.Limit)
+	}
+	return nil
+}
+
+// validateSettings 校验配置文件中的全局设置，0 表示使用默认值，时长为负数表示禁用
+func (c *Config) validateSettings() error {
+	checks := []struct {
+		path string
+		bad  bool
+		msg  string
+	}{
+		{"server.port", c.Server.Port < 0 || c.Server.Port > 65535, "端口超出范围"},
+		{"fetch.workers", c.Fetch.Workers < 0, "并发抓取数不能为负数"},
+		{"fetch.jitter_min", c.Fetch.JitterMin < 0, "请求延迟不能为负数"},
+		{"fetch.jitter_max", c.Fetch.JitterMax < 0, "请求延迟不能为负数"},
+		{"fetch.jitter_max", c.Fetch.JitterMax > 0 && c.Fetch.JitterMin > c.Fetch.JitterMax, "请求延迟上限小于下限"},
+		{"render.limit", c.Render.Limit < 0, "默认显示数量不能为负数"},
+		{"render.collapse_after", c.Render.CollapseAfter < 0, "折叠数量不能为负数"},
+		{"render.collapse_after_rows", c.Render.CollapseAfterRows < 0, "折叠行数不能为负数"},
+	}
+	for _, check := range checks {
+		if check.bad {
+			return &FieldError{Path: check.path, Err: fmt.Errorf("%s", check.msg)}
+		}
+	}
+	return nil
+}
+
+// Duration 配置中的时长，支持 "90s"、"5m"、"1h30m" 等写法，纯数字按秒计
+type Duration time.Duration
+
+// ParseDuration 解析配置中的时长
+func ParseDuration(s string) (Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return Duration(time.Duration(n) * time.Second), nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("无法解析时长: %s", s)
+	}
+	return Duration(d), nil
+}
+
+// Std 返回标准库时长
+func (d Duration) Std() time.Duration {
+	return time.Duration(d)
+}
+
+// Seconds 返回秒数，用于以秒为单位的缓存时间
+func (d Duration) Seconds() int {
+	return int(time.Duration(d).Seconds())
+}
+
+func (d Duration) String() string {
+	return time.Duration(d).String()
+}
+
+// MarshalJSON 输出为时长字符串
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON 解析时长字符串或秒数
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	switch v := raw.(type) {
+	case float64:
+		*d = Duration(time.Duration(v * float64(time.Second)))
+		return nil
+	case string:
+		parsed, err := ParseDuration(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return fmt.Errorf("无法解析时长: %s", data)
+	}
+}
+
+// MarshalYAML 输出为时长字符串
+func (d Duration) MarshalYAML() (interface{}, error) {
+	return d.String(), nil
+}
+
+// UnmarshalYAML 解析时长字符串或秒数
+func (d *Duration) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("第 %d 行: 无法解析时长", node.Line)
+	}
+	parsed, err := ParseDuration(node.Value)
+	if err != nil {
+		return fmt.Errorf("第 %d 行: %w", node.Line, err)
+	}
+	*d = parsed
+	return nil
+}
@@ -0,0 +1,99 @@
+// Package config 全局设置单元测试
+package config
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// TestConfig_Settings 测试全局设置的默认值与环境变量覆盖
+func TestConfig_Settings(t *testing.T) {
+	for _, env := range []string{"PORT", "ADMIN_TOKEN", "DEFAULT_LIMIT", "CACHE_TTL", "FETCH_WORKERS"} {
+		t.Setenv(env, "")
+	}
+
+	cfg := &Config{
+		Server: ServerConfig{Port: 9000},
+		Fetch:  FetchConfig{JitterMin: Duration(2 * time.Second), ProfileRefresh: Duration(-1)},
+	}
+	s, err := cfg.Settings()
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+	if s.Server.Port != 9000 || s.Render.Limit != DefaultLimit || s.Fetch.Workers != DefaultWorkers {
+		t.Errorf("Settings() = %+v", s)
+	}
+	if s.Cache.TTL.Std() != DefaultCacheTTL || s.Render.CollapseAfterRows != DefaultCollapseRows {
+		t.Errorf("默认值 = %+v %+v", s.Cache, s.Render)
+	}
+	if s.Fetch.JitterMax < s.Fetch.JitterMin {
+		t.Errorf("延迟上限 %s 小于下限 %s", s.Fetch.JitterMax, s.Fetch.JitterMin)
+	}
+	if s.Fetch.ProfileRefresh > 0 || s.Fetch.CookieRefresh.Std() != DefaultCookieRefresh {
+		t.Errorf("刷新间隔 = %+v", s.Fetch)
+	}
+	if err := s.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	t.Setenv("PORT", "8000")
+	t.Setenv("ADMIN_TOKEN", "secret")
+	t.Setenv("CACHE_TTL", "60")
+	s, err = cfg.Settings()
+	if err != nil {
+		t.Fatalf("Settings() error = %v", err)
+	}
+	if s.Server.Port != 8000 || s.Server.AdminToken != "secret" || s.Cache.TTL.Seconds() != 60 {
+		t.Errorf("环境变量覆盖后 = %+v %+v", s.Server, s.Cache)
+	}
+	if cfg.Server.Port != 9000 || cfg.Server.AdminToken != "" {
+		t.Error("环境变量不应修改配置")
+	}
+
+	// 负数为禁用，命令行参数覆盖为 0 时恢复默认值
+	t.Setenv("CACHE_TTL", "-1")
+	s, err = cfg.Settings()
+	if err != nil || s.Cache.TTL >= 0 {
+		t.Errorf("CACHE_TTL=-1 时缓存时间 = %s, err = %v, want 禁用", s.Cache.TTL, err)
+	}
+	s.Server.ConfigWatch = 0
+	s.ApplyDefaults()
+	if s.Server.ConfigWatch.Std() != DefaultConfigWatch || s.Cache.TTL >= 0 {
+		t.Errorf("ApplyDefaults() = %+v %+v", s.Server, s.Cache)
+	}
+
+	t.Setenv("FETCH_WORKERS", "many")
+	if _, err := cfg.Settings(); err == nil {
+		t.Error("非法环境变量应返回错误")
+	}
+}
+
+// TestDuration 测试时长解析
+func TestDuration(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"时长字符串", `"1h30m"`, 90 * time.Minute, false},
+		{"数字字符串按秒计", `"300"`, 5 * time.Minute, false},
+		{"数字按秒计", `1.5`, 1500 * time.Millisecond, false},
+		{"非法字符串", `"soon"`, 0, true},
+		{"非法类型", `true`, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var d Duration
+			err := json.Unmarshal([]byte(tt.input), &d)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("UnmarshalJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if d.Std() != tt.want {
+				t.Errorf("UnmarshalJSON() = %s, want %s", d, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package models
 
 import (
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -17,19 +19,109 @@ type Video struct {
 	Duration     string    `json:"duration"`      // 视频时长
 	PlayCount    int       `json:"play_count"`    // 播放次数
 	Bvid         string    `json:"bvid"`          // BV 号
+	Tid          int       `json:"tid,omitempty"` // 分区 ID（UP 主投稿、热门与排行榜来源）
+
+	AuthorMid  string `json:"author_mid,omitempty"`  // UP 主 MID（番剧来源为空）
+	AuthorFace string `json:"author_face,omitempty"` // UP 主头像（已缓存频道资料时填充）
+
+	Episode     string    `json:"episode,omitempty"`     // 角标，如番剧的 "EP 12"、专栏文章的 "专栏"
+	FavoritedAt time.Time `json:"favorited_at,omitzero"` // 收藏时间（仅收藏夹来源）
+	SortTime    time.Time `json:"-"`                     // 汇总排序使用的时间，为空时使用发布时间
+	Progress    int       `json:"progress,omitempty"`    // 观看进度百分比，100 为已看完（仅稍后再看、历史记录来源）
+
+	Stats       *VideoStats `json:"stats,omitempty"`       // 互动数据（仅开启数据补全时）
+	Description string      `json:"description,omitempty"` // 视频简介（仅开启数据补全时）
+	Tags        []string    `json:"tags,omitempty"`        // 视频标签（仅开启数据补全时）
+}
+
+// VideoStats 视频互动数据
+type VideoStats struct {
+	Like     int `json:"like"`     // 点赞数
+	Coin     int `json:"coin"`     // 投币数
+	Favorite int `json:"favorite"` // 收藏数
+	Share    int `json:"share"`    // 分享数
+	Danmaku  int `json:"danmaku"`  // 弹幕数
+	Reply    int `json:"reply"`    // 评论数
+}
+
+// VideoDetail 视频详情，用于补全列表接口未返回的数据
+type VideoDetail struct {
+	Bvid        string
+	PlayCount   int
+	Stats       VideoStats
+	Description string
+	Tags        []string
+}
+
+// ApplyDetail 使用视频详情补全互动数据、简介与标签
+func (v *Video) ApplyDetail(d VideoDetail) {
+	stats := d.Stats
+	v.Stats = &stats
+	v.Description = d.Description
+	v.Tags = d.Tags
+	// 详情接口的播放数更新，列表接口可能未返回播放数
+	if d.PlayCount > v.PlayCount {
+		v.PlayCount = d.PlayCount
+	}
+}
+
+// Finished 是否已看完
+func (v Video) Finished() bool {
+	return v.Progress >= 100
+}
+
+// DurationSeconds 将 "mm:ss" 或 "h:mm:ss" 格式的时长转换为秒数，无法解析时返回 0
+func (v Video) DurationSeconds() int {
+	if v.Duration == "" {
+		return 0
+	}
+	total := 0
+	for _, part := range strings.Split(v.Duration, ":") {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0
+		}
+		total = total*60 + n
+	}
+	return total
+}
+
+// sortKey 返回用于排序的时间
+func (v Video) sortKey() time.Time {
+	if !v.SortTime.IsZero() {
+		return v.SortTime
+	}
+	return v.TimePosted
 }
 
 // VideoList 视频列表类型
 type VideoList []Video
 
 // SortByNewest 按发布时间倒序排序（最新在前）
+// 设置了 SortTime 的视频（如按收藏时间排序的收藏夹）使用 SortTime 参与排序
 func (v VideoList) SortByNewest() VideoList {
-	sort.Slice(v, func(i, j int) bool {
-		return v[i].TimePosted.After(v[j].TimePosted)
+	sort.SliceStable(v, func(i, j int) bool {
+		return v[i].sortKey().After(v[j].sortKey())
 	})
 	return v
 }
 
+// DedupeByBvid 按 BV 号去重，保留首次出现的视频
+func (v VideoList) DedupeByBvid() VideoList {
+	seen := make(map[string]bool, len(v))
+	result := make(VideoList, 0, len(v))
+	for _, video := range v {
+		if video.Bvid != "" {
+			if seen[video.Bvid] {
+				continue
+			}
+			seen[video.Bvid] = true
+		}
+		result = append(result, video)
+	}
+	return result
+}
+
 // Limit 限制返回数量
 func (v VideoList) Limit(n int) VideoList {
 	if n <= 0 || n >= len(v) {
@@ -37,3 +129,312 @@ func (v VideoList) Limit(n int) VideoList {
 	}
 	return v[:n]
 }
+
+// VideoPage 分页浏览时的一页视频
+type VideoPage struct {
+	Videos     VideoList `json:"videos"`                // 本页视频
+	Page       int       `json:"page,omitempty"`        // 当前页码（使用游标时为 0）
+	NextPage   int       `json:"next_page,omitempty"`   // 下一页页码，无更多数据时为 0
+	NextCursor string    `json:"next_cursor,omitempty"` // 下一页游标，无更多数据时为空
+}
+
+// 直播间状态
+const (
+	LiveStatusOffline = 0 // 未开播
+	LiveStatusLive    = 1 // 直播中
+	LiveStatusRound   = 2 // 轮播中
+)
+
+// LiveRoom 表示 UP 主直播间的状态
+type LiveRoom struct {
+	Mid        string    `json:"mid"`         // 用户 UID
+	RoomID     string    `json:"room_id"`     // 直播间 ID（未开通直播间时为空）
+	Title      string    `json:"title"`       // 直播间标题
+	CoverUrl   string    `json:"cover_url"`   // 直播间封面 URL
+	Url        string    `json:"url"`         // 直播间链接
+	Author     string    `json:"author"`      // 主播名称
+	AuthorUrl  string    `json:"author_url"`  // 主播主页链接
+	Area       string    `json:"area"`        // 分区名称
+	ParentArea string    `json:"parent_area"` // 父分区名称
+	Online     int       `json:"online"`      // 人气值
+	Status     int       `json:"status"`      // 直播状态: 0 未开播, 1 直播中, 2 轮播中
+	LiveSince  time.Time `json:"live_since"`  // 本次开播时间（仅直播中有效）
+}
+
+// IsLive 是否正在直播
+func (r LiveRoom) IsLive() bool {
+	return r.Status == LiveStatusLive
+}
+
+// LiveRoomList 直播间列表类型
+type LiveRoomList []LiveRoom
+
+// SortByStatus 按直播状态排序：直播中在前，其次按人气倒序
+func (l LiveRoomList) SortByStatus() LiveRoomList {
+	sort.SliceStable(l, func(i, j int) bool {
+		if l[i].IsLive() != l[j].IsLive() {
+			return l[i].IsLive()
+		}
+		return l[i].Online > l[j].Online
+	})
+	return l
+}
+
+// OnlyLive 仅保留正在直播的直播间
+func (l LiveRoomList) OnlyLive() LiveRoomList {
+	result := make(LiveRoomList, 0, len(l))
+	for _, r := range l {
+		if r.IsLive() {
+			result = append(result, r)
+		}
+	}
+	return result
+}
+
+// Limit 限制返回数量
+func (l LiveRoomList) Limit(n int) LiveRoomList {
+	if n <= 0 || n >= len(l) {
+		return l
+	}
+	return l[:n]
+}
+
+// Channel 表示 UP 主的频道资料
+type Channel struct {
+	Mid       string    `json:"mid"`        // 用户 UID
+	Name      string    `json:"name"`       // 昵称
+	Url       string    `json:"url"`        // 主页链接
+	Face      string    `json:"face"`       // 头像 URL
+	Sign      string    `json:"sign"`       // 个性签名
+	Level     int       `json:"level"`      // 用户等级
+	Follower  int       `json:"follower"`   // 粉丝数
+	Archives  int       `json:"archives"`   // 投稿数
+	UpdatedAt time.Time `json:"updated_at"` // 资料获取时间
+}
+
+// ChannelList 频道资料列表类型
+type ChannelList []Channel
+
+// SortByFollower 按粉丝数倒序排序
+func (l ChannelList) SortByFollower() ChannelList {
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].Follower > l[j].Follower
+	})
+	return l
+}
+
+// LoginStatus 登录状态
+type LoginStatus struct {
+	Configured bool      `json:"configured"`          // 是否配置了登录凭据
+	LoggedIn   bool      `json:"logged_in"`           // 凭据是否有效
+	Mid        string    `json:"mid,omitempty"`       // 登录用户 UID
+	Uname      string    `json:"uname,omitempty"`     // 登录用户昵称
+	CheckedAt  time.Time `json:"checked_at,omitzero"` // 最近一次校验时间
+}
+
+// IdentityStatus 请求身份池中单个身份的状态
+type IdentityStatus struct {
+	Name             string    `json:"name"`
+	Primary          bool      `json:"primary"`                    // 是否为主身份（个人来源固定使用）
+	LoggedIn         bool      `json:"logged_in"`                  // 是否携带登录 Cookie
+	UserAgent        string    `json:"user_agent,omitempty"`       // 为空时使用默认 User-Agent
+	Buvid3           string    `json:"buvid3,omitempty"`           // buvid3 前 8 位
+	Requests         int       `json:"requests"`                   // 分配次数
+	Throttled        int       `json:"throttled"`                  // 命中风控次数
+	Quarantined      bool      `json:"quarantined"`                // 是否处于隔离期
+	QuarantinedUntil time.Time `json:"quarantined_until,omitzero"` // 隔离结束时间
+	LastUsed         time.Time `json:"last_used,omitzero"`
+	LastThrottled    time.Time `json:"last_throttled,omitzero"`
+}
+
+// PoolStatus 请求身份池状态
+type PoolStatus struct {
+	Strategy   string           `json:"strategy"`
+	Identities []IdentityStatus `json:"identities"`
+}
+
+// ReloadResult 配置重载结果
+type ReloadResult struct {
+	Time     time.Time `json:"time"`
+	Trigger  string    `json:"trigger"` // 触发方式: watch（文件变化）、admin（管理接口）
+	Success  bool      `json:"success"`
+	Error    string    `json:"error,omitempty"`
+	Channels int       `json:"channels"`          // 重载后 channels 的数量
+	Added    []string  `json:"added,omitempty"`   // 新增或重新启用的来源
+	Removed  []string  `json:"removed,omitempty"` // 移除或停用的来源
+	Evicted  int       `json:"evicted"`           // 清除的缓存条目数
+	Restart  []string  `json:"restart,omitempty"` // 已修改但需重启才生效的配置段
+}
+
+// FetchError 一次抓取失败的记录，供管理后台查看
+type FetchError struct {
+	Time   time.Time `json:"time"`
+	Kind   string    `json:"kind"`           // 数据类型: videos、live、dynamics、articles、channels、details
+	Source string    `json:"source"`         // 来源标识，如缓存键、mid 或 BV 号
+	Name   string    `json:"name,omitempty"` // 来源名称
+	Error  string    `json:"error"`
+	Stale  bool      `json:"stale"` // 是否已使用过期缓存兜底
+}
+
+// CacheSection 某一类缓存的统计
+type CacheSection struct {
+	Kind    string             `json:"kind"` // 数据类型，与 FetchError.Kind 一致
+	Entries []CacheEntryStatus `json:"entries"`
+}
+
+// CacheEntryStatus 单个缓存条目的状态
+type CacheEntryStatus struct {
+	Key       string    `json:"key"`
+	Items     int       `json:"items"` // 条目中的数据数量
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+}
+
+// QRLoginState 扫码登录状态
+type QRLoginState string
+
+const (
+	QRLoginWaiting   QRLoginState = "waiting"   // 未扫码
+	QRLoginScanned   QRLoginState = "scanned"   // 已扫码，等待手机确认
+	QRLoginExpired   QRLoginState = "expired"   // 二维码已失效
+	QRLoginConfirmed QRLoginState = "confirmed" // 已确认登录
+)
+
+// QRLogin 扫码登录二维码
+type QRLogin struct {
+	URL string `json:"url"` // 二维码内容
+	Key string `json:"key"` // 轮询登录状态使用的 qrcode_key
+}
+
+// ArticleBadge 专栏文章合并进视频汇总时显示的角标
+const ArticleBadge = "专栏"
+
+// Article 表示单篇专栏文章
+type Article struct {
+	ID            string    `json:"id"`             // 文章 ID（cv 号数字部分）
+	Title         string    `json:"title"`          // 标题
+	Summary       string    `json:"summary"`        // 摘要
+	BannerUrl     string    `json:"banner_url"`     // 头图 URL
+	Url           string    `json:"url"`            // 文章链接
+	Author        string    `json:"author"`         // 作者名称
+	AuthorUrl     string    `json:"author_url"`     // 作者主页链接
+	AuthorMid     string    `json:"author_mid"`     // 作者 MID
+	Category      string    `json:"category"`       // 分类名称
+	Words         int       `json:"words"`          // 字数
+	TimePublished time.Time `json:"time_published"` // 发布时间
+	ViewCount     int       `json:"view_count"`     // 阅读数
+	LikeCount     int       `json:"like_count"`     // 点赞数
+	ReplyCount    int       `json:"reply_count"`    // 评论数
+}
+
+// ToVideo 将专栏文章转换为视频条目，用于合并进视频汇总
+func (a Article) ToVideo() Video {
+	return Video{
+		Title:        a.Title,
+		ThumbnailUrl: a.BannerUrl,
+		Url:          a.Url,
+		Author:       a.Author,
+		AuthorUrl:    a.AuthorUrl,
+		AuthorMid:    a.AuthorMid,
+		TimePosted:   a.TimePublished,
+		PlayCount:    a.ViewCount,
+		Episode:      ArticleBadge,
+		Description:  a.Summary,
+	}
+}
+
+// ArticleList 专栏文章列表类型
+type ArticleList []Article
+
+// SortByNewest 按发布时间倒序排序
+func (l ArticleList) SortByNewest() ArticleList {
+	sort.SliceStable(l, func(i, j int) bool {
+		return l[i].TimePublished.After(l[j].TimePublished)
+	})
+	return l
+}
+
+// Limit 限制返回数量
+func (l ArticleList) Limit(n int) ArticleList {
+	if n <= 0 || n >= len(l) {
+		return l
+	}
+	return l[:n]
+}
+
+// Videos 将文章列表转换为视频列表
+func (l ArticleList) Videos() VideoList {
+	videos := make(VideoList, 0, len(l))
+	for _, a := range l {
+		videos = append(videos, a.ToVideo())
+	}
+	return videos
+}
+
+// 动态条目类型
+const (
+	FeedItemTypeVideo   = "video"   // 投稿视频
+	FeedItemTypeDraw    = "draw"    // 图文
+	FeedItemTypeForward = "forward" // 转发
+	FeedItemTypeArticle = "article" // 专栏
+	FeedItemTypeLive    = "live"    // 直播
+	FeedItemTypeText    = "text"    // 纯文字及其他
+)
+
+// FeedItem 表示单条动态
+// 不同类型的动态共用该结构，仅填充各自相关的字段
+type FeedItem struct {
+	ID         string    `json:"id"`                 // 动态 ID
+	Type       string    `json:"type"`               // 动态类型
+	Url        string    `json:"url"`                // 动态或内容链接
+	Author     string    `json:"author"`             // 发布者名称
+	AuthorUrl  string    `json:"author_url"`         // 发布者主页链接
+	AuthorFace string    `json:"author_face"`        // 发布者头像 URL
+	TimePosted time.Time `json:"time_posted"`        // 发布时间
+	Text       string    `json:"text"`               // 正文
+	Title      string    `json:"title,omitempty"`    // 标题（视频/专栏/直播）
+	Cover      string    `json:"cover,omitempty"`    // 封面（视频/专栏/直播）
+	Images     []string  `json:"images,omitempty"`   // 图片列表（图文）
+	Duration   string    `json:"duration,omitempty"` // 视频时长
+	Bvid       string    `json:"bvid,omitempty"`     // 视频 BV 号
+	Likes      int       `json:"likes"`              // 点赞数
+	Comments   int       `json:"comments"`           // 评论数
+	Forwards   int       `json:"forwards"`           // 转发数
+	Orig       *FeedItem `json:"orig,omitempty"`     // 被转发的原动态
+}
+
+// FeedItemList 动态列表类型
+type FeedItemList []FeedItem
+
+// SortByNewest 按发布时间倒序排序（最新在前）
+func (f FeedItemList) SortByNewest() FeedItemList {
+	sort.SliceStable(f, func(i, j int) bool {
+		return f[i].TimePosted.After(f[j].TimePosted)
+	})
+	return f
+}
+
+// Limit 限制返回数量
+func (f FeedItemList) Limit(n int) FeedItemList {
+	if n <= 0 || n >= len(f) {
+		return f
+	}
+	return f[:n]
+}
+
+// FilterTypes 仅保留指定类型的动态，types 为空时不过滤
+func (f FeedItemList) FilterTypes(types []string) FeedItemList {
+	if len(types) == 0 {
+		return f
+	}
+	allowed := make(map[string]bool, len(types))
+	for _, t := range types {
+		allowed[t] = true
+	}
+	result := make(FeedItemList, 0, len(f))
+	for _, item := range f {
+		if allowed[item.Type] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -163,3 +163,143 @@ func TestVideoList_SortByNewest_Limit_Chain(t *testing.T) {
 		t.Errorf("第二个视频应该是 BV2, got %s", result[1].Bvid)
 	}
 }
+
+// TestLiveRoomList_SortByStatus 测试直播间按状态排序与过滤
+func TestLiveRoomList_SortByStatus(t *testing.T) {
+	rooms := LiveRoomList{
+		{Mid: "1", Status: LiveStatusOffline, Online: 100},
+		{Mid: "2", Status: LiveStatusLive, Online: 10},
+		{Mid: "3", Status: LiveStatusRound, Online: 50},
+		{Mid: "4", Status: LiveStatusLive, Online: 20},
+	}
+
+	result := rooms.SortByStatus()
+	expected := []string{"4", "2", "1", "3"}
+	for i, mid := range expected {
+		if result[i].Mid != mid {
+			t.Errorf("位置 %d: got %s, want %s", i, result[i].Mid, mid)
+		}
+	}
+
+	live := result.OnlyLive()
+	if len(live) != 2 {
+		t.Fatalf("OnlyLive() got %d rooms, want 2", len(live))
+	}
+	for _, r := range live {
+		if !r.IsLive() {
+			t.Errorf("OnlyLive() 包含未开播直播间: %s", r.Mid)
+		}
+	}
+}
+
+// TestVideoList_SortByNewest_SortTime 测试设置 SortTime 的视频参与排序
+func TestVideoList_SortByNewest_SortTime(t *testing.T) {
+	now := time.Now()
+	videos := VideoList{
+		{Bvid: "BV1", TimePosted: now.Add(-1 * time.Hour)},
+		// 发布时间最早，但收藏时间最新
+		{Bvid: "BV2", TimePosted: now.Add(-72 * time.Hour), SortTime: now},
+		{Bvid: "BV3", TimePosted: now.Add(-2 * time.Hour)},
+	}
+
+	result := videos.SortByNewest()
+	expected := []string{"BV2", "BV1", "BV3"}
+	for i, bvid := range expected {
+		if result[i].Bvid != bvid {
+			t.Errorf("位置 %d: got %s, want %s", i, result[i].Bvid, bvid)
+		}
+	}
+}
+
+// TestVideoList_DedupeByBvid 测试按 BV 号去重
+func TestVideoList_DedupeByBvid(t *testing.T) {
+	videos := VideoList{
+		{Bvid: "BV1", Author: "频道"},
+		{Bvid: "BV2"},
+		{Bvid: "BV1", Author: "搜索"},
+		{Bvid: "BV3"},
+	}
+
+	result := videos.DedupeByBvid()
+	if len(result) != 3 {
+		t.Fatalf("got %d videos, want 3", len(result))
+	}
+	if result[0].Author != "频道" {
+		t.Errorf("应保留首次出现的视频, got %s", result[0].Author)
+	}
+}
+
+// TestVideo_ApplyDetail 测试使用视频详情补全数据
+func TestVideo_ApplyDetail(t *testing.T) {
+	v := Video{Bvid: "BV1", PlayCount: 500}
+	v.ApplyDetail(VideoDetail{
+		Bvid:        "BV1",
+		PlayCount:   800,
+		Stats:       VideoStats{Like: 10, Danmaku: 3},
+		Description: "简介",
+		Tags:        []string{"科技"},
+	})
+
+	if v.Stats == nil || v.Stats.Like != 10 || v.Stats.Danmaku != 3 {
+		t.Errorf("Stats = %+v, want Like=10 Danmaku=3", v.Stats)
+	}
+	if v.PlayCount != 800 {
+		t.Errorf("PlayCount = %d, want 800", v.PlayCount)
+	}
+	if v.Description != "简介" || len(v.Tags) != 1 {
+		t.Errorf("Description = %q, Tags = %v", v.Description, v.Tags)
+	}
+
+	// 详情播放数较旧时保留列表中的播放数
+	v.ApplyDetail(VideoDetail{Bvid: "BV1", PlayCount: 100})
+	if v.PlayCount != 800 {
+		t.Errorf("PlayCount = %d, want 800", v.PlayCount)
+	}
+}
+
+// TestVideo_DurationSeconds 测试时长字符串转换为秒数
+func TestVideo_DurationSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration string
+		expected int
+	}{
+		{"分秒", "03:25", 205},
+		{"时分秒", "1:02:03", 3723},
+		{"空", "", 0},
+		{"无法解析", "直播回放", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := (Video{Duration: tt.duration}).DurationSeconds(); got != tt.expected {
+				t.Errorf("DurationSeconds() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+// TestArticleList_Videos 测试专栏文章转换为视频条目
+func TestArticleList_Videos(t *testing.T) {
+	now := time.Now()
+	articles := ArticleList{
+		{ID: "1", Title: "旧文章", Url: "https://www.bilibili.com/read/cv1", TimePublished: now.Add(-time.Hour)},
+		{ID: "2", Title: "新文章", Url: "https://www.bilibili.com/read/cv2", TimePublished: now, Summary: "摘要"},
+	}
+
+	videos := articles.SortByNewest().Videos()
+	if len(videos) != 2 {
+		t.Fatalf("got %d videos, want 2", len(videos))
+	}
+	if videos[0].Title != "新文章" || videos[0].Description != "摘要" {
+		t.Errorf("videos[0] = %+v", videos[0])
+	}
+	if videos[0].Episode != ArticleBadge {
+		t.Errorf("Episode = %s, want %s", videos[0].Episode, ArticleBadge)
+	}
+
+	// 文章没有 BV 号，去重时不应被合并
+	if got := len(videos.DedupeByBvid()); got != 2 {
+		t.Errorf("DedupeByBvid() len = %d, want 2", got)
+	}
+}
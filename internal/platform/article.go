@@ -0,0 +1,129 @@
+// Package platform 提供专栏文章获取功能
+package platform
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// articlePageSize 专栏列表接口单页数量
+	articlePageSize = 30
+	// maxArticlePages 单次获取专栏列表的最大翻页数
+	maxArticlePages = 3
+)
+
+// articleInfo 专栏列表接口返回的文章结构
+type articleInfo struct {
+	ID          int64    `json:"id"`
+	Title       string   `json:"title"`
+	Summary     string   `json:"summary"`
+	BannerUrl   string   `json:"banner_url"`
+	ImageUrls   []string `json:"image_urls"`
+	PublishTime int64    `json:"publish_time"`
+	Words       int      `json:"words"`
+	Category    struct {
+		Name string `json:"name"`
+	} `json:"category"`
+	Author struct {
+		Mid  int64  `json:"mid"`
+		Name string `json:"name"`
+	} `json:"author"`
+	Stats struct {
+		View  int `json:"view"`
+		Like  int `json:"like"`
+		Reply int `json:"reply"`
+	} `json:"stats"`
+}
+
+// articleListResponse 用于解析 UP 主专栏列表接口响应
+type articleListResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Articles []articleInfo `json:"articles"`
+		Pn       int           `json:"pn"`
+		Ps       int           `json:"ps"`
+		Count    int           `json:"count"`
+	} `json:"data"`
+}
+
+// FetchUserArticles 获取 UP 主的专栏文章（最新在前）
+// authorOverride 如果非空，则用它作为作者名称
+func (c *BilibiliClient) FetchUserArticles(mid string, limit int, authorOverride string) (models.ArticleList, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (models.ArticleList, error) {
+		return c.fetchUserArticlesOnce(mid, limit, authorOverride)
+	})
+}
+
+func (c *BilibiliClient) fetchUserArticlesOnce(mid string, limit int, authorOverride string) (models.ArticleList, error) {
+	articles := make(models.ArticleList, 0, limit)
+	for page := 1; page <= maxArticlePages && len(articles) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		params := url.Values{}
+		params.Set("mid", mid)
+		params.Set("pn", strconv.Itoa(page))
+		params.Set("ps", strconv.Itoa(articlePageSize))
+		params.Set("sort", "publish_time")
+
+		var apiResp articleListResponse
+		if err := c.getSpaceSigned(mid, "https://api.bilibili.com/x/space/wbi/article", params, &apiResp); err != nil {
+			return nil, err
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, a := range apiResp.Data.Articles {
+			if len(articles) >= limit {
+				break
+			}
+			articles = append(articles, convertArticle(a, authorOverride))
+		}
+
+		if len(apiResp.Data.Articles) == 0 || page*articlePageSize >= apiResp.Data.Count {
+			break
+		}
+	}
+
+	return articles, nil
+}
+
+// convertArticle 将接口返回的文章转换为 Article
+func convertArticle(a articleInfo, authorOverride string) models.Article {
+	author := a.Author.Name
+	if authorOverride != "" {
+		author = authorOverride
+	}
+
+	// 部分文章没有头图，使用正文首图代替
+	banner := a.BannerUrl
+	if banner == "" && len(a.ImageUrls) > 0 {
+		banner = a.ImageUrls[0]
+	}
+
+	return models.Article{
+		ID:            strconv.FormatInt(a.ID, 10),
+		Title:         a.Title,
+		Summary:       a.Summary,
+		BannerUrl:     normalizeJumpUrl(banner),
+		Url:           fmt.Sprintf("https://www.bilibili.com/read/cv%d", a.ID),
+		Author:        author,
+		AuthorUrl:     fmt.Sprintf("https://space.bilibili.com/%d", a.Author.Mid),
+		AuthorMid:     strconv.FormatInt(a.Author.Mid, 10),
+		Category:      a.Category.Name,
+		Words:         a.Words,
+		TimePublished: time.Unix(a.PublishTime, 0),
+		ViewCount:     a.Stats.View,
+		LikeCount:     a.Stats.Like,
+		ReplyCount:    a.Stats.Reply,
+	}
+}
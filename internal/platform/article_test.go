@@ -0,0 +1,60 @@
+// Package platform 专栏文章单元测试
+package platform
+
+import "testing"
+
+// TestConvertArticle 测试专栏文章转换
+func TestConvertArticle(t *testing.T) {
+	tests := []struct {
+		name           string
+		article        articleInfo
+		authorOverride string
+		wantAuthor     string
+		wantBanner     string
+	}{
+		{
+			name: "使用头图",
+			article: articleInfo{
+				ID:        123,
+				BannerUrl: "https://i0.hdslb.com/banner.jpg",
+				ImageUrls: []string{"https://i0.hdslb.com/first.jpg"},
+			},
+			wantBanner: "https://i0.hdslb.com/banner.jpg",
+		},
+		{
+			name:       "无头图时使用首图",
+			article:    articleInfo{ID: 123, ImageUrls: []string{"//i0.hdslb.com/first.jpg"}},
+			wantBanner: "https://i0.hdslb.com/first.jpg",
+		},
+		{
+			name:           "配置名称优先",
+			article:        articleInfo{ID: 123},
+			authorOverride: "别名",
+			wantAuthor:     "别名",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.article.Author.Mid = 946974
+			if tt.wantAuthor == "" {
+				tt.article.Author.Name = "原名"
+				tt.wantAuthor = "原名"
+			}
+
+			a := convertArticle(tt.article, tt.authorOverride)
+			if a.Url != "https://www.bilibili.com/read/cv123" {
+				t.Errorf("Url = %s", a.Url)
+			}
+			if a.Author != tt.wantAuthor {
+				t.Errorf("Author = %s, want %s", a.Author, tt.wantAuthor)
+			}
+			if a.BannerUrl != tt.wantBanner {
+				t.Errorf("BannerUrl = %s, want %s", a.BannerUrl, tt.wantBanner)
+			}
+			if a.AuthorMid != "946974" {
+				t.Errorf("AuthorMid = %s, want 946974", a.AuthorMid)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+// Package platform 提供番剧（PGC）剧集获取功能
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// bangumiSeasonResponse 用于解析番剧详情接口响应
+type bangumiSeasonResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Result  struct {
+		SeasonID int64  `json:"season_id"`
+		MediaID  int64  `json:"media_id"`
+		Title    string `json:"title"`
+		Episodes []struct {
+			ID        int64  `json:"id"`
+			Title     string `json:"title"` // 集数，如 "12"
+			LongTitle string `json:"long_title"`
+			Cover     string `json:"cover"`
+			PubTime   int64  `json:"pub_time"`
+			Duration  int    `json:"duration"` // 毫秒
+			Bvid      string `json:"bvid"`
+		} `json:"episodes"`
+		Stat struct {
+			Views int `json:"views"`
+		} `json:"stat"`
+	} `json:"result"`
+}
+
+// FetchBangumiEpisodes 获取番剧最新的剧集（最新在前）
+// authorOverride 如果非空，则用它代替番剧标题作为作者名称
+func (c *BilibiliClient) FetchBangumiEpisodes(seasonID string, limit int, authorOverride string) (models.VideoList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchBangumiEpisodesOnce(seasonID, limit, authorOverride)
+	})
+}
+
+func (c *BilibiliClient) fetchBangumiEpisodesOnce(seasonID string, limit int, authorOverride string) (models.VideoList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	var apiResp bangumiSeasonResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/bangumi/play/ss"+seasonID).
+		SetQueryParam("season_id", seasonID).
+		SetResult(&apiResp).
+		Get("https://api.bilibili.com/pgc/view/web/season")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	season := apiResp.Result
+	author := authorOverride
+	if author == "" {
+		author = season.Title
+	}
+
+	// 接口按集数正序返回，倒序遍历以获取最新剧集
+	videos := make(models.VideoList, 0, limit)
+	for i := len(season.Episodes) - 1; i >= 0 && len(videos) < limit; i-- {
+		ep := season.Episodes[i]
+
+		title := ep.LongTitle
+		if title == "" {
+			title = season.Title
+		}
+
+		videos = append(videos, models.Video{
+			Title:        title,
+			ThumbnailUrl: ep.Cover,
+			Url:          fmt.Sprintf("https://www.bilibili.com/bangumi/play/ep%d", ep.ID),
+			Author:       author,
+			AuthorUrl:    fmt.Sprintf("https://www.bilibili.com/bangumi/media/md%d", season.MediaID),
+			TimePosted:   time.Unix(ep.PubTime, 0),
+			Duration:     formatDuration(ep.Duration / 1000),
+			Bvid:         ep.Bvid,
+			Episode:      episodeBadge(ep.Title),
+		})
+	}
+
+	return videos, nil
+}
+
+// episodeBadge 生成剧集角标：数字集数显示为 "EP 12"，其他（如 "正片"、"SP"）原样显示
+func episodeBadge(index string) string {
+	if _, err := strconv.ParseFloat(index, 64); err == nil {
+		return "EP " + index
+	}
+	return index
+}
@@ -0,0 +1,25 @@
+// Package platform 番剧剧集单元测试
+package platform
+
+import "testing"
+
+// TestEpisodeBadge 测试剧集角标生成
+func TestEpisodeBadge(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "12", expected: "EP 12"},
+		{input: "12.5", expected: "EP 12.5"},
+		{input: "正片", expected: "正片"},
+		{input: "SP", expected: "SP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := episodeBadge(tt.input); got != tt.expected {
+				t.Errorf("episodeBadge(%q) = %q, want %q", tt.input, got, tt.expected)
+			}
+		})
+	}
+}
@@ -11,6 +11,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/go-resty/resty/v2"
+
 	"glance-bilibili/internal/logger"
 	"glance-bilibili/internal/models"
 )
@@ -31,6 +33,7 @@ type bilibiliResponse struct {
 				Created     int64  `json:"created"`
 				Length      string `json:"length"`
 				Play        int    `json:"play"`
+				Typeid      int    `json:"typeid"`
 				Description string `json:"description"`
 			} `json:"vlist"`
 		} `json:"list"`
@@ -52,21 +55,54 @@ type buvidResponse struct {
 }
 
 // BilibiliClient Bilibili API 客户端
+// 请求的身份（Cookie、buvid、User-Agent）来自全局身份池：
+// 未绑定身份时每个请求按分配策略从身份池选取，绑定后所有请求使用同一身份
 type BilibiliClient struct {
-	wbiKeys    *WbiKeys
-	buvid3     string
-	buvid4     string
-	buvidMu    sync.RWMutex
-	webidCache map[string]string
-	webidMu    sync.RWMutex
+	wbiKeys *WbiKeys
+	webids  *webidCache
+	ident   *identity // 绑定的身份，为空时每个请求从身份池分配
+}
+
+// webidCache 各 UP 主空间页的 w_webid 缓存
+type webidCache struct {
+	values map[string]string
+	mu     sync.RWMutex
 }
 
 // NewBilibiliClient 创建新的 Bilibili 客户端
 func NewBilibiliClient() *BilibiliClient {
 	return &BilibiliClient{
-		wbiKeys:    GetWbiKeys(),
-		webidCache: make(map[string]string),
+		wbiKeys: GetWbiKeys(),
+		webids:  &webidCache{values: make(map[string]string)},
+	}
+}
+
+// withIdentity 返回绑定到指定身份的客户端，与原客户端共享 WBI 密钥与 w_webid 缓存
+func (c *BilibiliClient) withIdentity(id *identity) *BilibiliClient {
+	bound := *c
+	bound.ident = id
+	return &bound
+}
+
+// primaryClient 返回绑定到主身份的客户端，用于依赖登录用户本人的请求
+func (c *BilibiliClient) primaryClient() *BilibiliClient {
+	return c.withIdentity(identities.primary())
+}
+
+// R 创建携带身份 Cookie 与 User-Agent 的请求
+// 未绑定身份时按分配策略从身份池选取一个身份
+func (c *BilibiliClient) R() *resty.Request {
+	id := c.ident
+	if id == nil {
+		id = identities.acquire()
+		if err := id.ensureBuvid(); err != nil {
+			logger.Warnw("获取 buvid 失败",
+				"identity", id.Name,
+				"error", err,
+			)
+		}
 	}
+	return id.request()
 }
 
 // Initialize 初始化客户端（获取必要的密钥）
@@ -85,84 +121,39 @@ func (c *BilibiliClient) Initialize() error {
 }
 
 // ensureBuvid 确保已获取 buvid
+// 已绑定身份时仅检查该身份，否则检查身份池中的全部身份
 func (c *BilibiliClient) ensureBuvid() error {
-	c.buvidMu.RLock()
-	hasValue := c.buvid3 != "" && c.buvid4 != ""
-	c.buvidMu.RUnlock()
-
-	if hasValue {
-		return nil
-	}
-
-	c.buvidMu.Lock()
-	defer c.buvidMu.Unlock()
-
-	// Double check
-	if c.buvid3 != "" && c.buvid4 != "" {
-		return nil
-	}
-
-	// 使用 Resty 客户端
-	client := GetRestyClient()
-
-	var buvidResp buvidResponse
-	resp, err := client.R().
-		SetHeader("Referer", "https://www.bilibili.com/").
-		SetResult(&buvidResp).
-		Get("https://api.bilibili.com/x/frontend/finger/spi")
-
-	if err != nil {
-		return err
-	}
-
-	if !resp.IsSuccess() {
-		return fmt.Errorf("获取 buvid HTTP 错误: %d", resp.StatusCode())
+	if c.ident != nil {
+		return c.ident.ensureBuvid()
 	}
 
-	if buvidResp.Code != 0 {
-		return fmt.Errorf("获取 buvid 失败: code=%d", buvidResp.Code)
+	var firstErr error
+	for _, id := range identities.all() {
+		if err := id.ensureBuvid(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-
-	c.buvid3 = buvidResp.Data.B3
-	c.buvid4 = buvidResp.Data.B4
-	logger.Debugw("获取 buvid 成功",
-		"buvid3", c.buvid3[:8]+"...",
-		"buvid4", c.buvid4[:8]+"...",
-	)
-
-	return nil
-}
-
-// refreshBuvid 强制刷新 buvid，通常用于命中风控后的重试。
-func (c *BilibiliClient) refreshBuvid() error {
-	c.buvidMu.Lock()
-	c.buvid3 = ""
-	c.buvid4 = ""
-	c.buvidMu.Unlock()
-	return c.ensureBuvid()
+	return firstErr
 }
 
 // getWebid 获取 w_webid 参数
 func (c *BilibiliClient) getWebid(mid string) string {
-	c.webidMu.RLock()
-	if webid, ok := c.webidCache[mid]; ok {
-		c.webidMu.RUnlock()
+	c.webids.mu.RLock()
+	if webid, ok := c.webids.values[mid]; ok {
+		c.webids.mu.RUnlock()
 		return webid
 	}
-	c.webidMu.RUnlock()
+	c.webids.mu.RUnlock()
 
-	c.webidMu.Lock()
-	defer c.webidMu.Unlock()
+	c.webids.mu.Lock()
+	defer c.webids.mu.Unlock()
 
 	// Double check
-	if webid, ok := c.webidCache[mid]; ok {
+	if webid, ok := c.webids.values[mid]; ok {
 		return webid
 	}
 
-	// 使用 Resty 客户端
-	client := GetRestyClient()
-
-	resp, err := client.R().
+	resp, err := c.R().
 		Get(fmt.Sprintf("https://space.bilibili.com/%s/dynamic", mid))
 
 	if err != nil {
@@ -174,7 +165,7 @@ func (c *BilibiliClient) getWebid(mid string) string {
 	matches := re.FindSubmatch(body)
 	if len(matches) > 1 {
 		webid := string(matches[1])
-		c.webidCache[mid] = webid
+		c.webids.values[mid] = webid
 		return webid
 	}
 
@@ -183,9 +174,9 @@ func (c *BilibiliClient) getWebid(mid string) string {
 
 // invalidateWebid 清理指定 mid 的 w_webid 缓存，避免使用过期值重试。
 func (c *BilibiliClient) invalidateWebid(mid string) {
-	c.webidMu.Lock()
-	delete(c.webidCache, mid)
-	c.webidMu.Unlock()
+	c.webids.mu.Lock()
+	delete(c.webids.values, mid)
+	c.webids.mu.Unlock()
 }
 
 // getDmParams 生成 dm 相关参数
@@ -213,28 +204,109 @@ func retryBackoff(attempt int) time.Duration {
 	return time.Duration(attempt)*baseDelay + time.Duration(rand.Int63n(int64(maxJitter)))
 }
 
+// pageDelay 翻页请求之间的间隔，降低连续请求触发风控的概率
+func pageDelay() time.Duration {
+	baseDelay := 300 * time.Millisecond
+	maxJitter := 500 * time.Millisecond
+	return baseDelay + time.Duration(rand.Int63n(int64(maxJitter)))
+}
+
+const (
+	// userVideosPageSize 投稿列表接口单页最大数量
+	userVideosPageSize = 50
+	// maxUserVideosPages 单次获取投稿列表的最大翻页数
+	maxUserVideosPages = 20
+)
+
+// UserVideosPage UP 主投稿列表中的一页
+type UserVideosPage struct {
+	Videos models.VideoList // 本页视频
+	Total  int              // 投稿总数
+}
+
 // FetchUserVideos 获取指定用户的视频列表
+// limit 超过单页上限时自动翻页，页与页之间会间隔一段时间以避免触发风控
 // authorOverride 如果非空，则用它覆盖 API 返回的作者名称（解决联合投稿问题）
 func (c *BilibiliClient) FetchUserVideos(mid string, limit int, authorOverride string) (models.VideoList, error) {
+	pageSize := min(limit, userVideosPageSize)
+
+	videos := make(models.VideoList, 0, limit)
+	for pn := 1; pn <= maxUserVideosPages && len(videos) < limit; pn++ {
+		if pn > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		page, err := c.FetchUserVideosPage(mid, pn, pageSize, authorOverride)
+		if err != nil {
+			// 已获取部分数据时返回已有结果，避免深翻页失败导致整体不可用
+			if len(videos) > 0 {
+				logger.Warnw("翻页获取视频失败，返回已获取部分",
+					"up_mid", mid,
+					"page", pn,
+					"error", err,
+				)
+				break
+			}
+			return nil, err
+		}
+
+		for _, v := range page.Videos {
+			videos = append(videos, v)
+			if len(videos) >= limit {
+				break
+			}
+		}
+
+		if len(page.Videos) < pageSize || pn*pageSize >= page.Total {
+			break
+		}
+	}
+
+	return videos, nil
+}
+
+// FetchUserVideosPage 获取指定用户投稿列表的第 pn 页（从 1 开始），每页 ps 条（最多 50）
+func (c *BilibiliClient) FetchUserVideosPage(mid string, pn int, ps int, authorOverride string) (UserVideosPage, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (UserVideosPage, error) {
+		return c.fetchUserVideosOnce(mid, pn, min(ps, userVideosPageSize), authorOverride)
+	})
+}
+
+// withRiskControlRetry 执行请求，命中风控时隔离当前身份、刷新凭据并退避重试
+// 未绑定身份的客户端每次尝试从身份池分配一个身份，重试时会换用其他未被隔离的身份
+func withRiskControlRetry[T any](c *BilibiliClient, mid string, fetch func(c *BilibiliClient) (T, error)) (T, error) {
 	const maxAttempts = 3
 
+	var zero T
 	var lastErr error
 	for attempt := 1; attempt <= maxAttempts; attempt++ {
-		videos, err := c.fetchUserVideosOnce(mid, limit, authorOverride)
+		bound := c
+		if c.ident == nil {
+			bound = c.withIdentity(identities.acquire())
+		}
+
+		result, err := fetch(bound)
 		if err == nil {
-			return videos, nil
+			bound.ident.succeed()
+			return result, nil
 		}
 
 		lastErr = err
-		if !isRiskControlError(err) || attempt == maxAttempts {
+		if !isRiskControlError(err) {
 			break
 		}
 
-		logger.Warnw("命中风控，准备刷新凭据后重试",
+		quarantine := bound.ident.quarantine(time.Now())
+		logger.Warnw("命中风控，隔离当前身份",
 			"up_mid", mid,
+			"identity", bound.ident.Name,
+			"quarantine", quarantine,
 			"attempt", attempt,
 			"error", err,
 		)
+		if attempt == maxAttempts {
+			break
+		}
 
 		c.invalidateWebid(mid)
 		if refreshErr := c.wbiKeys.Update(); refreshErr != nil {
@@ -243,9 +315,10 @@ func (c *BilibiliClient) FetchUserVideos(mid string, limit int, authorOverride s
 				"error", refreshErr,
 			)
 		}
-		if refreshErr := c.refreshBuvid(); refreshErr != nil {
+		if refreshErr := bound.ident.refreshBuvid(); refreshErr != nil {
 			logger.Warnw("刷新 buvid 失败",
 				"up_mid", mid,
+				"identity", bound.ident.Name,
 				"error", refreshErr,
 			)
 		}
@@ -253,19 +326,19 @@ func (c *BilibiliClient) FetchUserVideos(mid string, limit int, authorOverride s
 		time.Sleep(retryBackoff(attempt))
 	}
 
-	return nil, lastErr
+	return zero, lastErr
 }
 
-func (c *BilibiliClient) fetchUserVideosOnce(mid string, limit int, authorOverride string) (models.VideoList, error) {
+func (c *BilibiliClient) fetchUserVideosOnce(mid string, pn int, ps int, authorOverride string) (UserVideosPage, error) {
 	if err := c.ensureBuvid(); err != nil {
-		return nil, err
+		return UserVideosPage{}, err
 	}
 
 	params := url.Values{}
 	params.Set("mid", mid)
 	params.Set("order", "pubdate")
-	params.Set("pn", "1")
-	params.Set("ps", strconv.Itoa(min(limit, 50)))
+	params.Set("pn", strconv.Itoa(pn))
+	params.Set("ps", strconv.Itoa(ps))
 	params.Set("jsonp", "jsonp")
 
 	// 添加 dm 参数
@@ -281,36 +354,28 @@ func (c *BilibiliClient) fetchUserVideosOnce(mid string, limit int, authorOverri
 	// WBI 签名
 	signedParams, err := c.wbiKeys.Sign(params)
 	if err != nil {
-		return nil, fmt.Errorf("WBI 签名失败: %w", err)
+		return UserVideosPage{}, fmt.Errorf("WBI 签名失败: %w", err)
 	}
 
 	apiURL := "https://api.bilibili.com/x/space/wbi/arc/search?" + signedParams.Encode()
 
-	// 使用 Resty 客户端
-	client := GetRestyClient()
-
-	c.buvidMu.RLock()
-	cookie := fmt.Sprintf("buvid3=%s; buvid4=%s", c.buvid3, c.buvid4)
-	c.buvidMu.RUnlock()
-
 	var apiResp bilibiliResponse
-	resp, err := client.R().
+	resp, err := c.R().
 		SetHeader("Referer", "https://space.bilibili.com/"+mid).
 		SetHeader("Origin", "https://space.bilibili.com").
-		SetHeader("Cookie", cookie).
 		SetResult(&apiResp).
 		Get(apiURL)
 
 	if err != nil {
-		return nil, err
+		return UserVideosPage{}, err
 	}
 
 	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		return UserVideosPage{}, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
 	}
 
 	if apiResp.Code != 0 {
-		return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		return UserVideosPage{}, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
 	}
 
 	videos := make(models.VideoList, 0, len(apiResp.Data.List.Vlist))
@@ -327,18 +392,20 @@ func (c *BilibiliClient) fetchUserVideosOnce(mid string, limit int, authorOverri
 			Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", v.Bvid),
 			Author:       author,
 			AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%s", mid),
+			AuthorMid:    mid,
 			TimePosted:   time.Unix(v.Created, 0),
 			Duration:     v.Length,
 			PlayCount:    v.Play,
 			Bvid:         v.Bvid,
+			Tid:          v.Typeid,
 		}
 		videos = append(videos, video)
-		if len(videos) >= limit {
-			break
-		}
 	}
 
-	return videos, nil
+	return UserVideosPage{
+		Videos: videos,
+		Total:  apiResp.Data.Page.Count,
+	}, nil
 }
 
 func isRiskControlError(err error) bool {
@@ -0,0 +1,92 @@
+// Package platform 提供 UP 主频道资料获取功能
+package platform
+
+import (
+	"fmt"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// cardResponse 用于解析用户名片接口响应
+type cardResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Card struct {
+			Mid       string `json:"mid"`
+			Name      string `json:"name"`
+			Face      string `json:"face"`
+			Sign      string `json:"sign"`
+			Fans      int    `json:"fans"`
+			LevelInfo struct {
+				CurrentLevel int `json:"current_level"`
+			} `json:"level_info"`
+		} `json:"card"`
+		Follower     int `json:"follower"`
+		ArchiveCount int `json:"archive_count"`
+	} `json:"data"`
+}
+
+// FetchChannelProfile 获取 UP 主的头像、粉丝数、签名与等级
+// nameOverride 如果非空，则用它作为昵称
+func (c *BilibiliClient) FetchChannelProfile(mid string, nameOverride string) (models.Channel, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (models.Channel, error) {
+		return c.fetchChannelProfileOnce(mid, nameOverride)
+	})
+}
+
+func (c *BilibiliClient) fetchChannelProfileOnce(mid string, nameOverride string) (models.Channel, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return models.Channel{}, err
+	}
+
+	var apiResp cardResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://space.bilibili.com/"+mid).
+		SetHeader("Origin", "https://space.bilibili.com").
+		SetQueryParams(map[string]string{
+			"mid":   mid,
+			"photo": "false",
+		}).
+		SetResult(&apiResp).
+		Get("https://api.bilibili.com/x/web-interface/card")
+
+	if err != nil {
+		return models.Channel{}, err
+	}
+
+	if !resp.IsSuccess() {
+		return models.Channel{}, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return models.Channel{}, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	return parseChannelCard(mid, nameOverride, &apiResp), nil
+}
+
+// parseChannelCard 将用户名片接口响应转换为 Channel
+func parseChannelCard(mid string, nameOverride string, apiResp *cardResponse) models.Channel {
+	card := apiResp.Data.Card
+	channel := models.Channel{
+		Mid:       mid,
+		Name:      card.Name,
+		Url:       fmt.Sprintf("https://space.bilibili.com/%s", mid),
+		Face:      normalizeJumpUrl(card.Face),
+		Sign:      card.Sign,
+		Level:     card.LevelInfo.CurrentLevel,
+		Follower:  apiResp.Data.Follower,
+		Archives:  apiResp.Data.ArchiveCount,
+		UpdatedAt: time.Now(),
+	}
+	if nameOverride != "" {
+		channel.Name = nameOverride
+	}
+	// 部分情况下外层 follower 为 0，使用名片中的粉丝数
+	if channel.Follower == 0 {
+		channel.Follower = card.Fans
+	}
+	return channel
+}
@@ -0,0 +1,64 @@
+// Package platform 频道资料单元测试
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestParseChannelCard 测试用户名片解析
+func TestParseChannelCard(t *testing.T) {
+	tests := []struct {
+		name         string
+		body         string
+		nameOverride string
+		wantName     string
+		wantFollower int
+		wantFace     string
+	}{
+		{
+			name:         "使用外层粉丝数",
+			body:         `{"code":0,"data":{"card":{"name":"老番茄","face":"https://i0.hdslb.com/a.jpg","sign":"签名","fans":1,"level_info":{"current_level":6}},"follower":20000000,"archive_count":300}}`,
+			wantName:     "老番茄",
+			wantFollower: 20000000,
+			wantFace:     "https://i0.hdslb.com/a.jpg",
+		},
+		{
+			name:         "外层粉丝数缺失时使用名片粉丝数",
+			body:         `{"code":0,"data":{"card":{"name":"UP","face":"//i0.hdslb.com/b.jpg","fans":123,"level_info":{"current_level":5}}}}`,
+			wantName:     "UP",
+			wantFollower: 123,
+			wantFace:     "https://i0.hdslb.com/b.jpg",
+		},
+		{
+			name:         "配置名称优先",
+			body:         `{"code":0,"data":{"card":{"name":"原名"},"follower":10}}`,
+			nameOverride: "别名",
+			wantName:     "别名",
+			wantFollower: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var apiResp cardResponse
+			if err := json.Unmarshal([]byte(tt.body), &apiResp); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			channel := parseChannelCard("123", tt.nameOverride, &apiResp)
+			if channel.Name != tt.wantName {
+				t.Errorf("Name = %s, want %s", channel.Name, tt.wantName)
+			}
+			if channel.Follower != tt.wantFollower {
+				t.Errorf("Follower = %d, want %d", channel.Follower, tt.wantFollower)
+			}
+			if channel.Face != tt.wantFace {
+				t.Errorf("Face = %s, want %s", channel.Face, tt.wantFace)
+			}
+			if channel.Url != "https://space.bilibili.com/123" {
+				t.Errorf("Url = %s", channel.Url)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+// Package platform 提供登录 Cookie 自动刷新功能
+package platform
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/logger"
+)
+
+// refreshPublicKey 生成 correspondPath 使用的 RSA 公钥
+const refreshPublicKey = `-----BEGIN PUBLIC KEY-----
+MIGfMA0GCSqGSIb3DQEBAQUAA4GNADCBiQKBgQDLgd2OAkcGVtoE3ThUREbio0Eg
+Uc/prcajMKXvkCKFCWhJYJcLkcM2DKKcSeFpD/j6Boy538YXnR6VhcuUJOhH2x71
+nzPjfdTcqMz7djHum0qSZA0AyCBDABUqCrfNgCiJ00Ra7GmRj+YCK1NJEuewlb40
+JNrRuoEUXpabUzGB8QIDAQAB
+-----END PUBLIC KEY-----`
+
+// refreshCSRFPattern 匹配 correspond 页面中的 refresh_csrf
+var refreshCSRFPattern = regexp.MustCompile(`<div id="1-name">\s*([^<\s]+)\s*</div>`)
+
+// cookieInfoResponse 用于解析 Cookie 刷新检查接口响应
+type cookieInfoResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Refresh   bool  `json:"refresh"`
+		Timestamp int64 `json:"timestamp"` // 毫秒
+	} `json:"data"`
+}
+
+// cookieRefreshResponse 用于解析刷新 Cookie 与确认刷新接口响应
+type cookieRefreshResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		RefreshToken string `json:"refresh_token"`
+	} `json:"data"`
+}
+
+// StartCookieRefresh 启动后台定期检查并刷新登录 Cookie，首次检查立即执行
+// 仅扫码登录得到的凭据带有 refresh_token，可以刷新；刷新后的凭据写入 path
+func (c *BilibiliClient) StartCookieRefresh(path string, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	refresh := func() {
+		refreshed, err := c.RefreshCookie(path)
+		if err != nil {
+			logger.Warnw("刷新登录 Cookie 失败",
+				"path", path,
+				"error", err,
+			)
+			return
+		}
+		if refreshed {
+			logger.Infow("登录 Cookie 已刷新", "path", path)
+		}
+	}
+
+	go func() {
+		refresh()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			refresh()
+		}
+	}()
+}
+
+// RefreshCookie 检查登录 Cookie 是否需要刷新，需要时完成刷新并写入 path
+// 返回是否进行了刷新；未登录或凭据没有 refresh_token 时不做任何操作
+func (c *BilibiliClient) RefreshCookie(path string) (bool, error) {
+	old := currentCredential()
+	if old.IsZero() || old.BiliJct == "" || old.RefreshToken == "" {
+		return false, nil
+	}
+	// 刷新的是主身份的登录凭据
+	c = c.primaryClient()
+
+	// 1. 检查是否需要刷新
+	timestamp, needRefresh, err := c.checkCookieRefresh(old)
+	if err != nil {
+		return false, err
+	}
+	if !needRefresh {
+		return false, nil
+	}
+
+	// 2. 生成 correspondPath 并获取 refresh_csrf
+	correspond, err := correspondPath(timestamp)
+	if err != nil {
+		return false, err
+	}
+	refreshCSRF, err := c.fetchRefreshCSRF(correspond)
+	if err != nil {
+		return false, err
+	}
+
+	// 3. 刷新 Cookie
+	fresh, err := c.refreshCookie(old, refreshCSRF)
+	if err != nil {
+		return false, err
+	}
+
+	// 新凭据立即生效，旧 refresh_token 在确认前仍然有效，先落盘避免丢失
+	if err := SaveCredentialFile(path, fresh); err != nil {
+		return false, err
+	}
+	SetCredential(fresh)
+
+	// 4. 确认刷新，使旧 refresh_token 失效
+	if err := c.confirmCookieRefresh(fresh, old.RefreshToken); err != nil {
+		logger.Warnw("确认刷新登录 Cookie 失败", "error", err)
+	}
+
+	// 重新获取 WBI 密钥，同时校验登录状态
+	if err := c.wbiKeys.Update(); err != nil {
+		logger.Warnw("刷新 Cookie 后更新 WBI 密钥失败", "error", err)
+	}
+	return true, nil
+}
+
+// checkCookieRefresh 查询 Cookie 是否需要刷新，返回服务端时间戳（毫秒）
+func (c *BilibiliClient) checkCookieRefresh(cr Credential) (int64, bool, error) {
+	var apiResp cookieInfoResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/").
+		SetQueryParam("csrf", cr.BiliJct).
+		SetResult(&apiResp).
+		Get("https://passport.bilibili.com/x/passport-login/web/cookie/info")
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	if !resp.IsSuccess() {
+		return 0, false, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return 0, false, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	return apiResp.Data.Timestamp, apiResp.Data.Refresh, nil
+}
+
+// fetchRefreshCSRF 请求 correspond 页面并提取 refresh_csrf
+func (c *BilibiliClient) fetchRefreshCSRF(correspond string) (string, error) {
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/").
+		Get("https://www.bilibili.com/correspond/1/" + correspond)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !resp.IsSuccess() {
+		return "", fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	return parseRefreshCSRF(resp.String())
+}
+
+// refreshCookie 使用 refresh_csrf 与 refresh_token 换取新的登录凭据
+func (c *BilibiliClient) refreshCookie(old Credential, refreshCSRF string) (Credential, error) {
+	var apiResp cookieRefreshResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/").
+		SetFormData(map[string]string{
+			"csrf":          old.BiliJct,
+			"refresh_csrf":  refreshCSRF,
+			"source":        "main_web",
+			"refresh_token": old.RefreshToken,
+		}).
+		SetResult(&apiResp).
+		Post("https://passport.bilibili.com/x/passport-login/web/cookie/refresh")
+
+	if err != nil {
+		return Credential{}, err
+	}
+
+	if !resp.IsSuccess() {
+		return Credential{}, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return Credential{}, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	fresh := Credential{DedeUserID: old.DedeUserID, RefreshToken: apiResp.Data.RefreshToken}
+	fresh.applyCookies(resp.Cookies())
+	if fresh.SESSDATA == "" || fresh.BiliJct == "" || fresh.RefreshToken == "" {
+		return Credential{}, fmt.Errorf("刷新接口未返回完整的登录凭据")
+	}
+	return fresh, nil
+}
+
+// confirmCookieRefresh 使用新凭据确认刷新，使旧 refresh_token 失效
+func (c *BilibiliClient) confirmCookieRefresh(fresh Credential, oldRefreshToken string) error {
+	var apiResp cookieRefreshResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/").
+		SetFormData(map[string]string{
+			"csrf":          fresh.BiliJct,
+			"refresh_token": oldRefreshToken,
+		}).
+		SetResult(&apiResp).
+		Post("https://passport.bilibili.com/x/passport-login/web/confirm/refresh")
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+	return nil
+}
+
+// correspondPath 使用 RSA-OAEP 加密 refresh_<毫秒时间戳>，生成 correspond 页面路径
+func correspondPath(timestamp int64) (string, error) {
+	block, _ := pem.Decode([]byte(refreshPublicKey))
+	if block == nil {
+		return "", fmt.Errorf("解析刷新公钥失败")
+	}
+	pub, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return "", fmt.Errorf("解析刷新公钥失败: %w", err)
+	}
+	rsaPub, ok := pub.(*rsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("刷新公钥不是 RSA 公钥")
+	}
+
+	encrypted, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, rsaPub, []byte("refresh_"+strconv.FormatInt(timestamp, 10)), nil)
+	if err != nil {
+		return "", fmt.Errorf("生成 correspondPath 失败: %w", err)
+	}
+	return hex.EncodeToString(encrypted), nil
+}
+
+// parseRefreshCSRF 从 correspond 页面中提取 refresh_csrf
+func parseRefreshCSRF(page string) (string, error) {
+	m := refreshCSRFPattern.FindStringSubmatch(page)
+	if m == nil {
+		return "", fmt.Errorf("correspond 页面中未找到 refresh_csrf")
+	}
+	return m[1], nil
+}
@@ -0,0 +1,79 @@
+// Package platform Cookie 刷新单元测试
+package platform
+
+import (
+	"testing"
+)
+
+// TestCorrespondPath 测试 correspondPath 生成
+func TestCorrespondPath(t *testing.T) {
+	a, err := correspondPath(1684466082000)
+	if err != nil {
+		t.Fatalf("correspondPath() error = %v", err)
+	}
+	// 1024 位 RSA 密文为 128 字节，即 256 个十六进制字符
+	if len(a) != 256 {
+		t.Errorf("len(correspondPath()) = %d, want 256", len(a))
+	}
+
+	// OAEP 带随机填充，相同输入的密文应不同
+	b, _ := correspondPath(1684466082000)
+	if a == b {
+		t.Error("两次生成的 correspondPath 相同")
+	}
+}
+
+// TestParseRefreshCSRF 测试从 correspond 页面提取 refresh_csrf
+func TestParseRefreshCSRF(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "正常页面",
+			page: `<html><body><div id="1-name">b0cc8411ded2f9db2cff2edb3123acac</div><div id="2-name"></div></body></html>`,
+			want: "b0cc8411ded2f9db2cff2edb3123acac",
+		},
+		{
+			name: "带空白",
+			page: "<div id=\"1-name\">\n  abc123\n</div>",
+			want: "abc123",
+		},
+		{
+			name:    "缺少 refresh_csrf",
+			page:    `<html><body><div id="2-name">x</div></body></html>`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseRefreshCSRF(tt.page)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseRefreshCSRF() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseRefreshCSRF() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestRefreshCookie_NoRefreshToken 测试没有 refresh_token 时不发起刷新
+func TestRefreshCookie_NoRefreshToken(t *testing.T) {
+	defer SetCredential(Credential{})
+
+	c := NewBilibiliClient()
+	for _, cr := range []Credential{
+		{},
+		{SESSDATA: "sess", BiliJct: "jct", DedeUserID: "42"},
+	} {
+		SetCredential(cr)
+		refreshed, err := c.RefreshCookie(t.TempDir() + "/credentials.json")
+		if refreshed || err != nil {
+			t.Errorf("凭据 %+v: RefreshCookie() = %v, %v", cr, refreshed, err)
+		}
+	}
+}
@@ -0,0 +1,170 @@
+// Package platform 提供登录凭据管理
+package platform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// Credential 登录凭据（浏览器 Cookie 中的 SESSDATA、bili_jct 与 DedeUserID）
+// RefreshToken 仅扫码登录时获得，用于刷新 Cookie
+type Credential struct {
+	SESSDATA     string `json:"sessdata"`
+	BiliJct      string `json:"bili_jct"`
+	DedeUserID   string `json:"dedeuserid"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// IsZero 是否未配置凭据
+func (cr Credential) IsZero() bool {
+	return cr.SESSDATA == ""
+}
+
+// cookie 生成凭据部分的 Cookie，未配置时返回空字符串
+func (cr Credential) cookie() string {
+	if cr.IsZero() {
+		return ""
+	}
+	parts := []string{"SESSDATA=" + cr.SESSDATA}
+	if cr.BiliJct != "" {
+		parts = append(parts, "bili_jct="+cr.BiliJct)
+	}
+	if cr.DedeUserID != "" {
+		parts = append(parts, "DedeUserID="+cr.DedeUserID)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// applyCookies 用响应 Set-Cookie 中的凭据字段覆盖当前值
+func (cr *Credential) applyCookies(cookies []*http.Cookie) {
+	for _, ck := range cookies {
+		switch ck.Name {
+		case "SESSDATA":
+			cr.SESSDATA = ck.Value
+		case "bili_jct":
+			cr.BiliJct = ck.Value
+		case "DedeUserID":
+			cr.DedeUserID = ck.Value
+		}
+	}
+}
+
+// credentialStore 全局登录凭据及其登录状态，所有客户端共享
+type credentialStore struct {
+	credential Credential
+	status     models.LoginStatus
+	mu         sync.RWMutex
+}
+
+// 全局登录凭据实例
+var credentials = &credentialStore{}
+
+// SetCredential 设置全局登录凭据，设置后所有请求均以登录身份发出
+// 登录状态会在下次更新 WBI 密钥时校验
+func SetCredential(cr Credential) {
+	credentials.mu.Lock()
+	credentials.credential = cr
+	credentials.status = models.LoginStatus{Configured: !cr.IsZero()}
+	credentials.mu.Unlock()
+}
+
+// GetLoginStatus 返回当前登录状态
+func GetLoginStatus() models.LoginStatus {
+	credentials.mu.RLock()
+	defer credentials.mu.RUnlock()
+	return credentials.status
+}
+
+// currentCredential 返回当前使用的登录凭据
+func currentCredential() Credential {
+	credentials.mu.RLock()
+	defer credentials.mu.RUnlock()
+	return credentials.credential
+}
+
+// credentialCookie 返回凭据部分的 Cookie
+func credentialCookie() string {
+	credentials.mu.RLock()
+	defer credentials.mu.RUnlock()
+	return credentials.credential.cookie()
+}
+
+// updateLoginStatus 根据 nav 接口的返回更新登录状态
+func updateLoginStatus(isLogin bool, mid int64, uname string) {
+	credentials.mu.Lock()
+	defer credentials.mu.Unlock()
+
+	status := models.LoginStatus{
+		Configured: !credentials.credential.IsZero(),
+		LoggedIn:   isLogin,
+		CheckedAt:  time.Now(),
+	}
+	if isLogin {
+		status.Mid = strconv.FormatInt(mid, 10)
+		status.Uname = uname
+	}
+
+	// 配置了凭据但未登录，通常是 SESSDATA 已过期
+	if status.Configured && !isLogin && (credentials.status.LoggedIn || credentials.status.CheckedAt.IsZero()) {
+		logger.Warnw("登录凭据无效或已过期，将以游客身份请求")
+	}
+	if isLogin && !credentials.status.LoggedIn {
+		logger.Infow("登录凭据有效",
+			"uname", uname,
+			"mid", status.Mid,
+		)
+	}
+	credentials.status = status
+}
+
+// LoadCredentialFile 读取凭据文件（扫码登录时写入）
+// 文件不存在时返回的错误满足 errors.Is(err, os.ErrNotExist)
+func LoadCredentialFile(path string) (Credential, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Credential{}, err
+	}
+
+	var cr Credential
+	if err := json.Unmarshal(data, &cr); err != nil {
+		return Credential{}, fmt.Errorf("解析凭据文件失败: %w", err)
+	}
+	return cr, nil
+}
+
+// SaveCredentialFile 将凭据写入文件，仅所有者可读写
+// 先写临时文件再重命名，避免写入中断导致凭据文件损坏
+func SaveCredentialFile(path string, cr Credential) error {
+	data, err := json.MarshalIndent(cr, "", "  ")
+	if err != nil {
+		return fmt.Errorf("序列化凭据失败: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".credentials-*")
+	if err != nil {
+		return fmt.Errorf("写入凭据文件失败: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("写入凭据文件失败: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("写入凭据文件失败: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("写入凭据文件失败: %w", err)
+	}
+	return nil
+}
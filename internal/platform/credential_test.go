@@ -0,0 +1,162 @@
+// Package platform 登录凭据单元测试
+package platform
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glance-bilibili/internal/models"
+)
+
+// TestCookieHeader_Credential 测试 Cookie 头携带登录凭据
+func TestCookieHeader_Credential(t *testing.T) {
+	c := &identity{primary: true, buvid3: "b3", buvid4: "b4"}
+	defer SetCredential(Credential{})
+
+	SetCredential(Credential{})
+	if got := c.cookieHeader(); got != "buvid3=b3; buvid4=b4" {
+		t.Errorf("未登录 cookieHeader() = %s", got)
+	}
+
+	SetCredential(Credential{SESSDATA: "sess", BiliJct: "jct", DedeUserID: "42"})
+	got := c.cookieHeader()
+	for _, want := range []string{"buvid3=b3", "SESSDATA=sess", "bili_jct=jct", "DedeUserID=42"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("cookieHeader() = %s, 缺少 %s", got, want)
+		}
+	}
+}
+
+// TestUpdateLoginStatus 测试登录状态更新
+func TestUpdateLoginStatus(t *testing.T) {
+	defer SetCredential(Credential{})
+
+	SetCredential(Credential{SESSDATA: "sess"})
+	if status := GetLoginStatus(); !status.Configured || status.LoggedIn {
+		t.Fatalf("设置凭据后 status = %+v", status)
+	}
+
+	updateLoginStatus(true, 42, "测试用户")
+	status := GetLoginStatus()
+	if !status.LoggedIn || status.Mid != "42" || status.Uname != "测试用户" || status.CheckedAt.IsZero() {
+		t.Errorf("登录后 status = %+v", status)
+	}
+
+	updateLoginStatus(false, 0, "")
+	status = GetLoginStatus()
+	if status.LoggedIn || status.Mid != "" || !status.Configured {
+		t.Errorf("凭据失效后 status = %+v", status)
+	}
+}
+
+// TestCredentialFile 测试凭据文件读写
+func TestCredentialFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+
+	if _, err := LoadCredentialFile(path); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("文件不存在时 err = %v", err)
+	}
+
+	want := Credential{SESSDATA: "sess", BiliJct: "jct", DedeUserID: "42", RefreshToken: "token"}
+	if err := SaveCredentialFile(path, want); err != nil {
+		t.Fatalf("SaveCredentialFile() error = %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("凭据文件权限 = %o, want 600", perm)
+	}
+
+	got, err := LoadCredentialFile(path)
+	if err != nil {
+		t.Fatalf("LoadCredentialFile() error = %v", err)
+	}
+	if got != want {
+		t.Errorf("LoadCredentialFile() = %+v, want %+v", got, want)
+	}
+}
+
+// TestParseQRPoll 测试扫码登录轮询结果解析
+func TestParseQRPoll(t *testing.T) {
+	newResp := func(code int, rawURL string) qrPollResponse {
+		var r qrPollResponse
+		r.Data.Code = code
+		r.Data.URL = rawURL
+		r.Data.RefreshToken = "token"
+		return r
+	}
+
+	tests := []struct {
+		name      string
+		resp      qrPollResponse
+		cookies   []*http.Cookie
+		wantState models.QRLoginState
+		wantCred  Credential
+		wantErr   bool
+	}{
+		{
+			name:      "未扫码",
+			resp:      newResp(qrCodeWaiting, ""),
+			wantState: models.QRLoginWaiting,
+		},
+		{
+			name:      "已扫码未确认",
+			resp:      newResp(qrCodeScanned, ""),
+			wantState: models.QRLoginScanned,
+		},
+		{
+			name:      "二维码失效",
+			resp:      newResp(qrCodeExpired, ""),
+			wantState: models.QRLoginExpired,
+		},
+		{
+			name: "凭据取自 Set-Cookie",
+			resp: newResp(qrCodeConfirmed, "https://passport.biligame.com/crossDomain?SESSDATA=fromurl"),
+			cookies: []*http.Cookie{
+				{Name: "SESSDATA", Value: "sess"},
+				{Name: "bili_jct", Value: "jct"},
+				{Name: "DedeUserID", Value: "42"},
+			},
+			wantState: models.QRLoginConfirmed,
+			wantCred:  Credential{SESSDATA: "sess", BiliJct: "jct", DedeUserID: "42", RefreshToken: "token"},
+		},
+		{
+			name:      "凭据取自跳转链接",
+			resp:      newResp(qrCodeConfirmed, "https://passport.biligame.com/crossDomain?DedeUserID=42&SESSDATA=sess%2C123&bili_jct=jct"),
+			wantState: models.QRLoginConfirmed,
+			wantCred:  Credential{SESSDATA: "sess,123", BiliJct: "jct", DedeUserID: "42", RefreshToken: "token"},
+		},
+		{
+			name:    "确认登录但缺少凭据",
+			resp:    newResp(qrCodeConfirmed, ""),
+			wantErr: true,
+		},
+		{
+			name:    "未知状态码",
+			resp:    newResp(-1, ""),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, cr, err := parseQRPoll(tt.resp, tt.cookies)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseQRPoll() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if state != tt.wantState {
+				t.Errorf("state = %s, want %s", state, tt.wantState)
+			}
+			if cr != tt.wantCred {
+				t.Errorf("credential = %+v, want %+v", cr, tt.wantCred)
+			}
+		})
+	}
+}
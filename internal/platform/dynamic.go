@@ -0,0 +1,293 @@
+// Package platform 提供动态（空间动态）获取功能
+package platform
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// maxDynamicPages 单次获取动态的最大翻页数，避免过多请求触发风控
+const maxDynamicPages = 3
+
+// dynamicResponse 用于解析空间动态接口响应
+type dynamicResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		HasMore bool          `json:"has_more"`
+		Offset  string        `json:"offset"`
+		Items   []dynamicItem `json:"items"`
+	} `json:"data"`
+}
+
+// dynamicItem 单条动态，不同类型的内容位于 module_dynamic.major 的不同字段
+type dynamicItem struct {
+	IDStr   string `json:"id_str"`
+	Type    string `json:"type"`
+	Modules struct {
+		Author struct {
+			Mid   int64  `json:"mid"`
+			Name  string `json:"name"`
+			Face  string `json:"face"`
+			PubTs int64  `json:"pub_ts"`
+			IsTop bool   `json:"is_top"`
+		} `json:"module_author"`
+		Dynamic struct {
+			Desc *struct {
+				Text string `json:"text"`
+			} `json:"desc"`
+			Major *dynamicMajor `json:"major"`
+		} `json:"module_dynamic"`
+		Stat struct {
+			Comment struct {
+				Count int `json:"count"`
+			} `json:"comment"`
+			Forward struct {
+				Count int `json:"count"`
+			} `json:"forward"`
+			Like struct {
+				Count int `json:"count"`
+			} `json:"like"`
+		} `json:"module_stat"`
+	} `json:"modules"`
+	Orig *dynamicItem `json:"orig"`
+}
+
+// dynamicMajor 动态主体内容
+type dynamicMajor struct {
+	Archive *struct {
+		Bvid         string `json:"bvid"`
+		Title        string `json:"title"`
+		Cover        string `json:"cover"`
+		Desc         string `json:"desc"`
+		DurationText string `json:"duration_text"`
+		Stat         struct {
+			Play string `json:"play"` // 如 "1.2万"
+		} `json:"stat"`
+	} `json:"archive"`
+	Draw *struct {
+		Items []struct {
+			Src string `json:"src"`
+		} `json:"items"`
+	} `json:"draw"`
+	Opus *struct {
+		Title   string `json:"title"`
+		JumpUrl string `json:"jump_url"`
+		Summary struct {
+			Text string `json:"text"`
+		} `json:"summary"`
+		Pics []struct {
+			Url string `json:"url"`
+		} `json:"pics"`
+	} `json:"opus"`
+	Article *struct {
+		ID     int64    `json:"id"`
+		Title  string   `json:"title"`
+		Desc   string   `json:"desc"`
+		Covers []string `json:"covers"`
+	} `json:"article"`
+	Live *struct {
+		ID    int64  `json:"id"`
+		Title string `json:"title"`
+		Cover string `json:"cover"`
+	} `json:"live"`
+	LiveRcmd *struct {
+		Content string `json:"content"` // 内容为 JSON 字符串
+	} `json:"live_rcmd"`
+}
+
+// liveRcmdContent 直播推荐动态中 content 字段的结构
+type liveRcmdContent struct {
+	LivePlayInfo struct {
+		Title  string `json:"title"`
+		Cover  string `json:"cover"`
+		RoomID int64  `json:"room_id"`
+	} `json:"live_play_info"`
+}
+
+// FetchUserDynamics 获取指定用户的空间动态
+// authorOverride 如果非空，则用它覆盖 API 返回的作者名称
+func (c *BilibiliClient) FetchUserDynamics(mid string, limit int, authorOverride string) (models.FeedItemList, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (models.FeedItemList, error) {
+		return c.fetchUserDynamicsOnce(mid, limit, authorOverride)
+	})
+}
+
+func (c *BilibiliClient) fetchUserDynamicsOnce(mid string, limit int, authorOverride string) (models.FeedItemList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	items := make(models.FeedItemList, 0, limit)
+	offset := ""
+	for page := 0; page < maxDynamicPages && len(items) < limit; page++ {
+		params := url.Values{}
+		params.Set("host_mid", mid)
+		params.Set("offset", offset)
+		params.Set("features", "itemOpusStyle")
+
+		// 添加 dm 参数
+		for k, v := range getDmParams() {
+			params[k] = v
+		}
+
+		// WBI 签名
+		signedParams, err := c.wbiKeys.Sign(params)
+		if err != nil {
+			return nil, fmt.Errorf("WBI 签名失败: %w", err)
+		}
+
+		apiURL := "https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/space?" + signedParams.Encode()
+
+		var apiResp dynamicResponse
+		resp, err := c.R().
+			SetHeader("Referer", "https://space.bilibili.com/"+mid+"/dynamic").
+			SetHeader("Origin", "https://space.bilibili.com").
+			SetResult(&apiResp).
+			Get(apiURL)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, raw := range apiResp.Data.Items {
+			// 置顶动态会打乱时间顺序，跳过
+			if raw.Modules.Author.IsTop {
+				continue
+			}
+			item := parseDynamicItem(raw)
+			if authorOverride != "" {
+				item.Author = authorOverride
+			}
+			items = append(items, item)
+			if len(items) >= limit {
+				break
+			}
+		}
+
+		if !apiResp.Data.HasMore || apiResp.Data.Offset == "" {
+			break
+		}
+		offset = apiResp.Data.Offset
+	}
+
+	return items, nil
+}
+
+// parseDynamicItem 将原始动态转换为通用的动态条目，转发动态会递归解析原动态
+func parseDynamicItem(raw dynamicItem) models.FeedItem {
+	author := raw.Modules.Author
+	item := models.FeedItem{
+		ID:         raw.IDStr,
+		Type:       dynamicItemType(raw.Type),
+		Url:        "https://t.bilibili.com/" + raw.IDStr,
+		Author:     author.Name,
+		AuthorUrl:  fmt.Sprintf("https://space.bilibili.com/%d", author.Mid),
+		AuthorFace: author.Face,
+		Likes:      raw.Modules.Stat.Like.Count,
+		Comments:   raw.Modules.Stat.Comment.Count,
+		Forwards:   raw.Modules.Stat.Forward.Count,
+	}
+	if author.PubTs > 0 {
+		item.TimePosted = time.Unix(author.PubTs, 0)
+	}
+	if desc := raw.Modules.Dynamic.Desc; desc != nil {
+		item.Text = desc.Text
+	}
+
+	if major := raw.Modules.Dynamic.Major; major != nil {
+		switch {
+		case major.Archive != nil:
+			item.Title = major.Archive.Title
+			item.Cover = major.Archive.Cover
+			item.Duration = major.Archive.DurationText
+			item.Bvid = major.Archive.Bvid
+			item.Url = fmt.Sprintf("https://www.bilibili.com/video/%s", major.Archive.Bvid)
+			if item.Text == "" {
+				item.Text = major.Archive.Desc
+			}
+		case major.Opus != nil:
+			// 新版图文动态，正文与图片位于 opus 中
+			item.Title = major.Opus.Title
+			if item.Text == "" {
+				item.Text = major.Opus.Summary.Text
+			}
+			for _, pic := range major.Opus.Pics {
+				item.Images = append(item.Images, pic.Url)
+			}
+			if item.Type == models.FeedItemTypeArticle && major.Opus.JumpUrl != "" {
+				item.Url = normalizeJumpUrl(major.Opus.JumpUrl)
+			}
+		case major.Draw != nil:
+			for _, pic := range major.Draw.Items {
+				item.Images = append(item.Images, pic.Src)
+			}
+		case major.Article != nil:
+			item.Title = major.Article.Title
+			if item.Text == "" {
+				item.Text = major.Article.Desc
+			}
+			if len(major.Article.Covers) > 0 {
+				item.Cover = major.Article.Covers[0]
+			}
+			item.Url = fmt.Sprintf("https://www.bilibili.com/read/cv%d", major.Article.ID)
+		case major.Live != nil:
+			item.Title = major.Live.Title
+			item.Cover = major.Live.Cover
+			item.Url = fmt.Sprintf("https://live.bilibili.com/%d", major.Live.ID)
+		case major.LiveRcmd != nil:
+			var content liveRcmdContent
+			if err := json.Unmarshal([]byte(major.LiveRcmd.Content), &content); err == nil {
+				item.Title = content.LivePlayInfo.Title
+				item.Cover = content.LivePlayInfo.Cover
+				item.Url = fmt.Sprintf("https://live.bilibili.com/%d", content.LivePlayInfo.RoomID)
+			}
+		}
+	}
+
+	if raw.Orig != nil && item.Type == models.FeedItemTypeForward {
+		orig := parseDynamicItem(*raw.Orig)
+		item.Orig = &orig
+	}
+
+	return item
+}
+
+// dynamicItemType 将接口返回的动态类型映射为内部类型
+func dynamicItemType(t string) string {
+	switch t {
+	case "DYNAMIC_TYPE_AV", "DYNAMIC_TYPE_UGC_SEASON":
+		return models.FeedItemTypeVideo
+	case "DYNAMIC_TYPE_DRAW":
+		return models.FeedItemTypeDraw
+	case "DYNAMIC_TYPE_FORWARD":
+		return models.FeedItemTypeForward
+	case "DYNAMIC_TYPE_ARTICLE":
+		return models.FeedItemTypeArticle
+	case "DYNAMIC_TYPE_LIVE", "DYNAMIC_TYPE_LIVE_RCMD":
+		return models.FeedItemTypeLive
+	default:
+		return models.FeedItemTypeText
+	}
+}
+
+// normalizeJumpUrl 补全接口返回的协议相对链接
+func normalizeJumpUrl(u string) string {
+	if strings.HasPrefix(u, "//") {
+		return "https:" + u
+	}
+	return u
+}
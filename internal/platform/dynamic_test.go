@@ -0,0 +1,88 @@
+// Package platform 动态解析单元测试
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+
+	"glance-bilibili/internal/models"
+)
+
+// TestParseDynamicItem 测试不同类型动态的解析
+func TestParseDynamicItem(t *testing.T) {
+	raw := `{
+		"id_str": "100",
+		"type": "DYNAMIC_TYPE_FORWARD",
+		"modules": {
+			"module_author": {"mid": 1, "name": "转发者", "pub_ts": 1700000000},
+			"module_dynamic": {"desc": {"text": "转发理由"}, "major": null},
+			"module_stat": {"like": {"count": 3}}
+		},
+		"orig": {
+			"id_str": "99",
+			"type": "DYNAMIC_TYPE_DRAW",
+			"modules": {
+				"module_author": {"mid": 2, "name": "原作者", "pub_ts": 1690000000},
+				"module_dynamic": {
+					"desc": null,
+					"major": {"opus": {"summary": {"text": "图文正文"}, "pics": [{"url": "a.jpg"}, {"url": "b.jpg"}]}}
+				}
+			}
+		}
+	}`
+
+	var item dynamicItem
+	if err := json.Unmarshal([]byte(raw), &item); err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	result := parseDynamicItem(item)
+	if result.Type != models.FeedItemTypeForward {
+		t.Errorf("Type = %s, want %s", result.Type, models.FeedItemTypeForward)
+	}
+	if result.Text != "转发理由" || result.Likes != 3 {
+		t.Errorf("转发内容解析错误: %+v", result)
+	}
+	if result.Orig == nil {
+		t.Fatal("Orig 不应为空")
+	}
+	if result.Orig.Type != models.FeedItemTypeDraw {
+		t.Errorf("Orig.Type = %s, want %s", result.Orig.Type, models.FeedItemTypeDraw)
+	}
+	if result.Orig.Text != "图文正文" || len(result.Orig.Images) != 2 {
+		t.Errorf("原动态解析错误: %+v", result.Orig)
+	}
+	if result.Orig.AuthorUrl != "https://space.bilibili.com/2" {
+		t.Errorf("Orig.AuthorUrl = %s", result.Orig.AuthorUrl)
+	}
+}
+
+// TestParseDynamicItem_Video 测试视频动态的解析
+func TestParseDynamicItem_Video(t *testing.T) {
+	raw := `{
+		"id_str": "200",
+		"type": "DYNAMIC_TYPE_AV",
+		"modules": {
+			"module_author": {"mid": 1, "name": "UP", "pub_ts": 1700000000},
+			"module_dynamic": {
+				"major": {"archive": {"bvid": "BV1xx", "title": "视频标题", "cover": "c.jpg", "duration_text": "10:00"}}
+			}
+		}
+	}`
+
+	var item dynamicItem
+	if err := json.Unmarshal([]byte(raw), &item); err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	result := parseDynamicItem(item)
+	if result.Type != models.FeedItemTypeVideo {
+		t.Errorf("Type = %s, want %s", result.Type, models.FeedItemTypeVideo)
+	}
+	if result.Url != "https://www.bilibili.com/video/BV1xx" {
+		t.Errorf("Url = %s", result.Url)
+	}
+	if result.Title != "视频标题" || result.Duration != "10:00" {
+		t.Errorf("视频内容解析错误: %+v", result)
+	}
+}
@@ -0,0 +1,148 @@
+// Package platform 提供收藏夹内容获取功能
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// favoritePageSize 收藏夹接口单页最大数量
+	favoritePageSize = 20
+	// maxFavoritePages 单次获取收藏夹的最大翻页数
+	maxFavoritePages = 5
+)
+
+// favoriteResponse 用于解析收藏夹内容接口响应
+type favoriteResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Info struct {
+			Title string `json:"title"`
+		} `json:"info"`
+		Medias []struct {
+			Type     int    `json:"type"` // 2 为视频
+			Title    string `json:"title"`
+			Cover    string `json:"cover"`
+			Duration int    `json:"duration"`
+			Attr     int    `json:"attr"` // 非 0 表示已失效
+			Upper    struct {
+				Mid  int64  `json:"mid"`
+				Name string `json:"name"`
+			} `json:"upper"`
+			CntInfo struct {
+				Play int `json:"play"`
+			} `json:"cnt_info"`
+			Pubtime int64  `json:"pubtime"`
+			FavTime int64  `json:"fav_time"`
+			Bvid    string `json:"bvid"`
+		} `json:"medias"`
+		HasMore bool `json:"has_more"`
+	} `json:"data"`
+}
+
+// FetchFavoriteVideos 获取公开收藏夹中的视频
+// order 为 fav_time 时按收藏时间排序，pubdate 时按视频发布时间排序
+func (c *BilibiliClient) FetchFavoriteVideos(mediaID string, limit int, order string) (models.VideoList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchFavoriteVideosOnce(mediaID, limit, order)
+	})
+}
+
+func (c *BilibiliClient) fetchFavoriteVideosOnce(mediaID string, limit int, order string) (models.VideoList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	// 收藏夹接口的排序参数: mtime 收藏时间, pubtime 发布时间
+	apiOrder := "mtime"
+	if order == "pubdate" {
+		apiOrder = "pubtime"
+	}
+
+	videos := make(models.VideoList, 0, limit)
+	for page := 1; page <= maxFavoritePages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		var apiResp favoriteResponse
+		resp, err := c.R().
+			SetHeader("Referer", "https://www.bilibili.com/").
+			SetQueryParams(map[string]string{
+				"media_id": mediaID,
+				"pn":       strconv.Itoa(page),
+				"ps":       strconv.Itoa(favoritePageSize),
+				"order":    apiOrder,
+				"type":     "0",
+				"platform": "web",
+			}).
+			SetResult(&apiResp).
+			Get("https://api.bilibili.com/x/v3/fav/resource/list")
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, m := range apiResp.Data.Medias {
+			// 跳过音频等非视频内容及已失效视频
+			if m.Type != 2 || m.Attr != 0 {
+				continue
+			}
+
+			video := models.Video{
+				Title:        m.Title,
+				ThumbnailUrl: m.Cover,
+				Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", m.Bvid),
+				Author:       m.Upper.Name,
+				AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%d", m.Upper.Mid),
+				AuthorMid:    strconv.FormatInt(m.Upper.Mid, 10),
+				TimePosted:   time.Unix(m.Pubtime, 0),
+				Duration:     formatDuration(m.Duration),
+				PlayCount:    m.CntInfo.Play,
+				Bvid:         m.Bvid,
+				FavoritedAt:  time.Unix(m.FavTime, 0),
+			}
+			// 按收藏时间排序时，汇总排序也使用收藏时间
+			if apiOrder == "mtime" {
+				video.SortTime = video.FavoritedAt
+			}
+			videos = append(videos, video)
+			if len(videos) >= limit {
+				break
+			}
+		}
+
+		if !apiResp.Data.HasMore {
+			break
+		}
+	}
+
+	return videos, nil
+}
+
+// formatDuration 将秒数格式化为与投稿列表一致的 mm:ss 或 h:mm:ss
+func formatDuration(seconds int) string {
+	if seconds <= 0 {
+		return ""
+	}
+	h := seconds / 3600
+	m := seconds % 3600 / 60
+	s := seconds % 60
+	if h > 0 {
+		return fmt.Sprintf("%d:%02d:%02d", h, m, s)
+	}
+	return fmt.Sprintf("%02d:%02d", m, s)
+}
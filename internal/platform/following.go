@@ -0,0 +1,101 @@
+// Package platform 提供关注列表获取功能
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// followingPageSize 关注列表接口单页最大数量
+	followingPageSize = 50
+	// maxFollowingPages 未登录时接口最多返回前 5 页（250 个）关注
+	maxFollowingPages = 5
+	// followingPrivacyCode 用户设置了关注列表不公开
+	followingPrivacyCode = 22115
+)
+
+// followingsResponse 用于解析关注列表接口响应
+type followingsResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		List []struct {
+			Mid   int64  `json:"mid"`
+			Uname string `json:"uname"`
+			Face  string `json:"face"`
+			Sign  string `json:"sign"`
+		} `json:"list"`
+		Total int `json:"total"`
+	} `json:"data"`
+}
+
+// FetchFollowings 获取用户公开的关注列表（按关注时间倒序）
+func (c *BilibiliClient) FetchFollowings(mid string) (models.ChannelList, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (models.ChannelList, error) {
+		return c.fetchFollowingsOnce(mid)
+	})
+}
+
+func (c *BilibiliClient) fetchFollowingsOnce(mid string) (models.ChannelList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	var channels models.ChannelList
+	for page := 1; page <= maxFollowingPages; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		var apiResp followingsResponse
+		resp, err := c.R().
+			SetHeader("Referer", "https://space.bilibili.com/"+mid+"/fans/follow").
+			SetHeader("Origin", "https://space.bilibili.com").
+			SetQueryParams(map[string]string{
+				"vmid":       mid,
+				"pn":         strconv.Itoa(page),
+				"ps":         strconv.Itoa(followingPageSize),
+				"order":      "desc",
+				"order_type": "attention",
+			}).
+			SetResult(&apiResp).
+			Get("https://api.bilibili.com/x/relation/followings")
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		}
+
+		if apiResp.Code == followingPrivacyCode {
+			return nil, fmt.Errorf("用户 %s 的关注列表未公开", mid)
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, f := range apiResp.Data.List {
+			followedMid := strconv.FormatInt(f.Mid, 10)
+			channels = append(channels, models.Channel{
+				Mid:  followedMid,
+				Name: f.Uname,
+				Url:  fmt.Sprintf("https://space.bilibili.com/%s", followedMid),
+				Face: normalizeJumpUrl(f.Face),
+				Sign: f.Sign,
+			})
+		}
+
+		if len(apiResp.Data.List) < followingPageSize || page*followingPageSize >= apiResp.Data.Total {
+			break
+		}
+	}
+
+	return channels, nil
+}
@@ -0,0 +1,209 @@
+// Package platform 提供稍后再看与观看历史获取功能
+package platform
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// historyPageSize 历史记录接口单页数量
+	historyPageSize = 20
+	// maxHistoryPages 单次获取历史记录的最大翻页数
+	maxHistoryPages = 5
+)
+
+// watchLaterResponse 用于解析稍后再看接口响应
+type watchLaterResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Count int `json:"count"`
+		List  []struct {
+			Bvid     string `json:"bvid"`
+			Title    string `json:"title"`
+			Pic      string `json:"pic"`
+			Pubdate  int64  `json:"pubdate"`
+			Duration int    `json:"duration"`
+			Owner    struct {
+				Mid  int64  `json:"mid"`
+				Name string `json:"name"`
+				Face string `json:"face"`
+			} `json:"owner"`
+			Stat struct {
+				View int `json:"view"`
+			} `json:"stat"`
+			Progress int   `json:"progress"` // 已观看秒数，-1 为已看完
+			AddAt    int64 `json:"add_at"`
+		} `json:"list"`
+	} `json:"data"`
+}
+
+// historyResponse 用于解析历史记录接口响应
+type historyResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Cursor struct {
+			Max    int64 `json:"max"`
+			ViewAt int64 `json:"view_at"`
+		} `json:"cursor"`
+		List []struct {
+			Title      string `json:"title"`
+			Cover      string `json:"cover"`
+			AuthorName string `json:"author_name"`
+			AuthorMid  int64  `json:"author_mid"`
+			AuthorFace string `json:"author_face"`
+			ViewAt     int64  `json:"view_at"`
+			Progress   int    `json:"progress"` // 已观看秒数，-1 为已看完
+			Duration   int    `json:"duration"`
+			History    struct {
+				Bvid     string `json:"bvid"`
+				Business string `json:"business"`
+			} `json:"history"`
+		} `json:"list"`
+	} `json:"data"`
+}
+
+// FetchWatchLater 获取登录用户的稍后再看列表（最近添加在前），固定使用主身份
+func (c *BilibiliClient) FetchWatchLater(limit int) (models.VideoList, error) {
+	if credentialCookie() == "" {
+		return nil, fmt.Errorf("稍后再看需要配置登录凭据")
+	}
+	return withRiskControlRetry(c.primaryClient(), "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchWatchLaterOnce(limit)
+	})
+}
+
+func (c *BilibiliClient) fetchWatchLaterOnce(limit int) (models.VideoList, error) {
+	var apiResp watchLaterResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/watchlater/").
+		SetResult(&apiResp).
+		Get("https://api.bilibili.com/x/v2/history/toview")
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	videos := make(models.VideoList, 0, min(limit, len(apiResp.Data.List)))
+	for _, item := range apiResp.Data.List {
+		if len(videos) >= limit {
+			break
+		}
+		videos = append(videos, models.Video{
+			Title:        item.Title,
+			ThumbnailUrl: item.Pic,
+			Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", item.Bvid),
+			Author:       item.Owner.Name,
+			AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%d", item.Owner.Mid),
+			AuthorMid:    strconv.FormatInt(item.Owner.Mid, 10),
+			AuthorFace:   item.Owner.Face,
+			TimePosted:   time.Unix(item.Pubdate, 0),
+			Duration:     formatDuration(item.Duration),
+			PlayCount:    item.Stat.View,
+			Bvid:         item.Bvid,
+			SortTime:     time.Unix(item.AddAt, 0),
+			Progress:     watchProgress(item.Progress, item.Duration),
+		})
+	}
+
+	return videos, nil
+}
+
+// FetchHistory 获取登录用户最近的视频观看历史（最近观看在前），固定使用主身份
+// 历史记录接口不返回发布时间，TimePosted 使用观看时间
+func (c *BilibiliClient) FetchHistory(limit int) (models.VideoList, error) {
+	if credentialCookie() == "" {
+		return nil, fmt.Errorf("观看历史需要配置登录凭据")
+	}
+	return withRiskControlRetry(c.primaryClient(), "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchHistoryOnce(limit)
+	})
+}
+
+func (c *BilibiliClient) fetchHistoryOnce(limit int) (models.VideoList, error) {
+	videos := make(models.VideoList, 0, limit)
+	var maxID, viewAt int64
+	for page := 1; page <= maxHistoryPages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		var apiResp historyResponse
+		resp, err := c.R().
+			SetHeader("Referer", "https://www.bilibili.com/account/history").
+			SetQueryParams(map[string]string{
+				"type":    "archive",
+				"ps":      strconv.Itoa(historyPageSize),
+				"max":     strconv.FormatInt(maxID, 10),
+				"view_at": strconv.FormatInt(viewAt, 10),
+			}).
+			SetResult(&apiResp).
+			Get("https://api.bilibili.com/x/web-interface/history/cursor")
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, item := range apiResp.Data.List {
+			if item.History.Business != "archive" || item.History.Bvid == "" {
+				continue
+			}
+			videos = append(videos, models.Video{
+				Title:        item.Title,
+				ThumbnailUrl: item.Cover,
+				Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", item.History.Bvid),
+				Author:       item.AuthorName,
+				AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%d", item.AuthorMid),
+				AuthorMid:    strconv.FormatInt(item.AuthorMid, 10),
+				AuthorFace:   item.AuthorFace,
+				TimePosted:   time.Unix(item.ViewAt, 0),
+				Duration:     formatDuration(item.Duration),
+				Bvid:         item.History.Bvid,
+				Progress:     watchProgress(item.Progress, item.Duration),
+			})
+			if len(videos) >= limit {
+				break
+			}
+		}
+
+		if len(apiResp.Data.List) < historyPageSize || apiResp.Data.Cursor.Max == 0 {
+			break
+		}
+		maxID, viewAt = apiResp.Data.Cursor.Max, apiResp.Data.Cursor.ViewAt
+	}
+
+	return videos, nil
+}
+
+// watchProgress 将已观看秒数换算为进度百分比，-1 表示已看完
+func watchProgress(progress, duration int) int {
+	switch {
+	case progress < 0:
+		return 100
+	case progress == 0 || duration <= 0:
+		return 0
+	default:
+		return min(progress*100/duration, 100)
+	}
+}
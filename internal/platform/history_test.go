@@ -0,0 +1,30 @@
+// Package platform 稍后再看与观看历史单元测试
+package platform
+
+import (
+	"testing"
+)
+
+// TestWatchProgress 测试观看进度换算
+func TestWatchProgress(t *testing.T) {
+	tests := []struct {
+		name     string
+		progress int
+		duration int
+		expected int
+	}{
+		{"已看完", -1, 600, 100},
+		{"未观看", 0, 600, 0},
+		{"看了一半", 300, 600, 50},
+		{"进度超出时长", 700, 600, 100},
+		{"时长未知", 300, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := watchProgress(tt.progress, tt.duration); got != tt.expected {
+				t.Errorf("watchProgress(%d, %d) = %d, want %d", tt.progress, tt.duration, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,350 @@
+// Package platform 提供请求身份池
+package platform
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// 身份分配策略
+const (
+	StrategyRoundRobin     = "round-robin"     // 轮询（默认）
+	StrategyLeastThrottled = "least-throttled" // 优先使用最久未命中风控的身份
+)
+
+const (
+	// quarantineBase 首次命中风控时的隔离时长，连续命中时翻倍
+	quarantineBase = 5 * time.Minute
+	// maxQuarantine 最长隔离时长
+	maxQuarantine = time.Hour
+	// primaryIdentityName 主身份名称
+	primaryIdentityName = "default"
+)
+
+// Identity 请求身份：一组登录 Cookie（为空时以游客身份请求）与 User-Agent
+// buvid 按身份单独获取
+type Identity struct {
+	Name       string
+	Credential Credential
+	UserAgent  string // 为空时使用默认 User-Agent
+}
+
+// identity 身份池中的身份及其运行状态
+type identity struct {
+	Identity
+	primary bool // 主身份使用全局登录凭据，个人来源（关注时间线、稍后再看等）固定使用主身份
+
+	buvid3  string
+	buvid4  string
+	buvidMu sync.RWMutex
+
+	requests         int
+	throttled        int
+	strikes          int // 连续命中风控次数，决定隔离时长
+	lastUsed         time.Time
+	lastThrottled    time.Time
+	quarantinedUntil time.Time
+	mu               sync.Mutex
+}
+
+// credentialCookie 返回该身份的登录 Cookie，主身份使用全局登录凭据
+func (id *identity) credentialCookie() string {
+	if id.primary {
+		return credentialCookie()
+	}
+	return id.Credential.cookie()
+}
+
+// cookieHeader 生成该身份的 Cookie 头
+func (id *identity) cookieHeader() string {
+	id.buvidMu.RLock()
+	cookie := fmt.Sprintf("buvid3=%s; buvid4=%s", id.buvid3, id.buvid4)
+	id.buvidMu.RUnlock()
+
+	if cred := id.credentialCookie(); cred != "" {
+		cookie += "; " + cred
+	}
+	return cookie
+}
+
+// request 创建携带该身份 Cookie 与 User-Agent 的请求
+func (id *identity) request() *resty.Request {
+	req := GetRestyClient().R().SetHeader("Cookie", id.cookieHeader())
+	if id.UserAgent != "" {
+		req.SetHeader("User-Agent", id.UserAgent)
+	}
+	return req
+}
+
+// ensureBuvid 确保该身份已获取 buvid
+func (id *identity) ensureBuvid() error {
+	id.buvidMu.RLock()
+	hasValue := id.buvid3 != "" && id.buvid4 != ""
+	id.buvidMu.RUnlock()
+
+	if hasValue {
+		return nil
+	}
+
+	id.buvidMu.Lock()
+	defer id.buvidMu.Unlock()
+
+	// Double check
+	if id.buvid3 != "" && id.buvid4 != "" {
+		return nil
+	}
+
+	req := GetRestyClient().R().SetHeader("Cookie", id.credentialCookie())
+	if id.UserAgent != "" {
+		req.SetHeader("User-Agent", id.UserAgent)
+	}
+
+	var buvidResp buvidResponse
+	resp, err := req.
+		SetHeader("Referer", "https://www.bilibili.com/").
+		SetResult(&buvidResp).
+		Get("https://api.bilibili.com/x/frontend/finger/spi")
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("获取 buvid HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if buvidResp.Code != 0 {
+		return fmt.Errorf("获取 buvid 失败: code=%d", buvidResp.Code)
+	}
+
+	id.buvid3 = buvidResp.Data.B3
+	id.buvid4 = buvidResp.Data.B4
+	logger.Debugw("获取 buvid 成功",
+		"identity", id.Name,
+		"buvid3", id.buvid3[:8]+"...",
+		"buvid4", id.buvid4[:8]+"...",
+	)
+
+	return nil
+}
+
+// refreshBuvid 强制刷新 buvid，通常用于命中风控后的重试
+func (id *identity) refreshBuvid() error {
+	id.buvidMu.Lock()
+	id.buvid3 = ""
+	id.buvid4 = ""
+	id.buvidMu.Unlock()
+	return id.ensureBuvid()
+}
+
+// quarantined 该身份在 now 时是否处于隔离期
+func (id *identity) quarantined(now time.Time) bool {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	return now.Before(id.quarantinedUntil)
+}
+
+// touch 记录一次分配
+func (id *identity) touch(now time.Time) {
+	id.mu.Lock()
+	id.requests++
+	id.lastUsed = now
+	id.mu.Unlock()
+}
+
+// quarantine 命中风控后隔离该身份，连续命中时隔离时长翻倍，返回隔离时长
+func (id *identity) quarantine(now time.Time) time.Duration {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+
+	id.throttled++
+	id.strikes++
+	id.lastThrottled = now
+
+	d := maxQuarantine
+	if id.strikes <= 8 && quarantineBase<<(id.strikes-1) < maxQuarantine {
+		d = quarantineBase << (id.strikes - 1)
+	}
+	id.quarantinedUntil = now.Add(d)
+	return d
+}
+
+// succeed 请求成功后清除连续命中风控的计数
+func (id *identity) succeed() {
+	id.mu.Lock()
+	id.strikes = 0
+	id.mu.Unlock()
+}
+
+// releaseAt 返回解除隔离的时间
+func (id *identity) releaseAt() time.Time {
+	id.mu.Lock()
+	defer id.mu.Unlock()
+	return id.quarantinedUntil
+}
+
+// lessThrottled 该身份是否比 other 更久未命中风控，相同时比较最近使用时间
+func (id *identity) lessThrottled(other *identity) bool {
+	id.mu.Lock()
+	throttled, used := id.lastThrottled, id.lastUsed
+	id.mu.Unlock()
+
+	other.mu.Lock()
+	otherThrottled, otherUsed := other.lastThrottled, other.lastUsed
+	other.mu.Unlock()
+
+	if !throttled.Equal(otherThrottled) {
+		return throttled.Before(otherThrottled)
+	}
+	return used.Before(otherUsed)
+}
+
+// status 返回该身份的状态
+func (id *identity) status(now time.Time) models.IdentityStatus {
+	id.mu.Lock()
+	status := models.IdentityStatus{
+		Name:          id.Name,
+		Primary:       id.primary,
+		UserAgent:     id.UserAgent,
+		Requests:      id.requests,
+		Throttled:     id.throttled,
+		LastUsed:      id.lastUsed,
+		LastThrottled: id.lastThrottled,
+	}
+	if now.Before(id.quarantinedUntil) {
+		status.Quarantined = true
+		status.QuarantinedUntil = id.quarantinedUntil
+	}
+	id.mu.Unlock()
+
+	status.LoggedIn = id.credentialCookie() != ""
+	id.buvidMu.RLock()
+	if len(id.buvid3) >= 8 {
+		status.Buvid3 = id.buvid3[:8] + "..."
+	}
+	id.buvidMu.RUnlock()
+	return status
+}
+
+// identityPool 请求身份池，所有客户端共享
+type identityPool struct {
+	identities []*identity // 第一个为主身份
+	strategy   string
+	next       int
+	mu         sync.Mutex
+}
+
+// 全局身份池实例，默认仅包含主身份
+var identities = newIdentityPool()
+
+func newIdentityPool() *identityPool {
+	return &identityPool{
+		identities: []*identity{{Identity: Identity{Name: primaryIdentityName}, primary: true}},
+		strategy:   StrategyRoundRobin,
+	}
+}
+
+// ConfigureIdentities 设置主身份之外的额外身份及分配策略
+// 主身份始终保留；额外身份的 buvid 与风控状态会被重置
+func ConfigureIdentities(extra []Identity, strategy string) {
+	identities.configure(extra, strategy)
+}
+
+// GetPoolStatus 返回身份池状态
+func GetPoolStatus() models.PoolStatus {
+	return identities.status()
+}
+
+func (p *identityPool) configure(extra []Identity, strategy string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	list := make([]*identity, 0, len(extra)+1)
+	list = append(list, p.identities[0])
+	for _, e := range extra {
+		list = append(list, &identity{Identity: e})
+	}
+	p.identities = list
+	p.next = 0
+	p.strategy = StrategyRoundRobin
+	if strategy == StrategyLeastThrottled {
+		p.strategy = strategy
+	}
+}
+
+// primary 返回主身份
+func (p *identityPool) primary() *identity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.identities[0]
+}
+
+// all 返回全部身份
+func (p *identityPool) all() []*identity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]*identity(nil), p.identities...)
+}
+
+// acquire 按分配策略选出一个未被隔离的身份
+// 全部身份都处于隔离期时，选择最早解除隔离的身份
+func (p *identityPool) acquire() *identity {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	available := make([]*identity, 0, len(p.identities))
+	for _, id := range p.identities {
+		if !id.quarantined(now) {
+			available = append(available, id)
+		}
+	}
+
+	var chosen *identity
+	switch {
+	case len(available) == 0:
+		chosen = p.identities[0]
+		for _, id := range p.identities[1:] {
+			if id.releaseAt().Before(chosen.releaseAt()) {
+				chosen = id
+			}
+		}
+	case p.strategy == StrategyLeastThrottled:
+		chosen = available[0]
+		for _, id := range available[1:] {
+			if id.lessThrottled(chosen) {
+				chosen = id
+			}
+		}
+	default:
+		chosen = available[p.next%len(available)]
+		p.next++
+	}
+
+	chosen.touch(now)
+	return chosen
+}
+
+// status 返回身份池状态
+func (p *identityPool) status() models.PoolStatus {
+	p.mu.Lock()
+	list := append([]*identity(nil), p.identities...)
+	strategy := p.strategy
+	p.mu.Unlock()
+
+	now := time.Now()
+	result := models.PoolStatus{
+		Strategy:   strategy,
+		Identities: make([]models.IdentityStatus, 0, len(list)),
+	}
+	for _, id := range list {
+		result.Identities = append(result.Identities, id.status(now))
+	}
+	return result
+}
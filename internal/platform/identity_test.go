@@ -0,0 +1,151 @@
+// Package platform 请求身份池单元测试
+package platform
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestPool 创建包含主身份与若干额外身份的身份池
+func newTestPool(strategy string, names ...string) *identityPool {
+	p := newIdentityPool()
+	extra := make([]Identity, 0, len(names))
+	for _, name := range names {
+		extra = append(extra, Identity{Name: name})
+	}
+	p.configure(extra, strategy)
+	return p
+}
+
+// TestIdentityPool_RoundRobin 测试轮询分配
+func TestIdentityPool_RoundRobin(t *testing.T) {
+	p := newTestPool(StrategyRoundRobin, "a", "b")
+
+	var got []string
+	for i := 0; i < 6; i++ {
+		got = append(got, p.acquire().Name)
+	}
+	want := "default,a,b,default,a,b"
+	if strings.Join(got, ",") != want {
+		t.Errorf("分配顺序 = %v, want %s", got, want)
+	}
+}
+
+// TestIdentityPool_Quarantine 测试隔离与解除隔离
+func TestIdentityPool_Quarantine(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+	}{
+		{"轮询", StrategyRoundRobin},
+		{"最久未命中风控", StrategyLeastThrottled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPool(tt.strategy, "a")
+			p.identities[0].quarantine(time.Now())
+
+			for i := 0; i < 3; i++ {
+				if got := p.acquire().Name; got != "a" {
+					t.Fatalf("主身份隔离期间分配到 %s", got)
+				}
+			}
+		})
+	}
+}
+
+// TestIdentityPool_AllQuarantined 测试全部身份隔离时选择最早解除隔离的身份
+func TestIdentityPool_AllQuarantined(t *testing.T) {
+	p := newTestPool(StrategyRoundRobin, "a", "b")
+	now := time.Now()
+	p.identities[0].quarantine(now)
+	p.identities[0].quarantine(now) // 连续命中，隔离更久
+	p.identities[1].quarantine(now)
+	p.identities[2].quarantine(now.Add(time.Minute))
+
+	if got := p.acquire().Name; got != "a" {
+		t.Errorf("acquire() = %s, want a", got)
+	}
+}
+
+// TestIdentityPool_LeastThrottled 测试优先分配最久未命中风控的身份
+func TestIdentityPool_LeastThrottled(t *testing.T) {
+	p := newTestPool(StrategyLeastThrottled, "a", "b")
+	past := time.Now().Add(-2 * maxQuarantine)
+
+	// 三个身份都已解除隔离，b 命中风控最早
+	p.identities[0].quarantine(past.Add(2 * time.Minute))
+	p.identities[1].quarantine(past.Add(time.Minute))
+	p.identities[2].quarantine(past)
+
+	if got := p.acquire().Name; got != "b" {
+		t.Errorf("acquire() = %s, want b", got)
+	}
+
+	// 从未命中风控的身份优先，相同时选择最久未使用的
+	p = newTestPool(StrategyLeastThrottled, "a", "b")
+	got := []string{p.acquire().Name, p.acquire().Name, p.acquire().Name}
+	if strings.Join(got, ",") != "default,a,b" {
+		t.Errorf("分配顺序 = %v, want [default a b]", got)
+	}
+}
+
+// TestIdentity_QuarantineBackoff 测试连续命中风控时隔离时长翻倍并封顶
+func TestIdentity_QuarantineBackoff(t *testing.T) {
+	id := &identity{}
+	now := time.Now()
+
+	want := []time.Duration{5 * time.Minute, 10 * time.Minute, 20 * time.Minute, 40 * time.Minute, time.Hour, time.Hour}
+	for i, w := range want {
+		if got := id.quarantine(now); got != w {
+			t.Errorf("第 %d 次隔离时长 = %v, want %v", i+1, got, w)
+		}
+	}
+
+	id.succeed()
+	if got := id.quarantine(now); got != quarantineBase {
+		t.Errorf("成功后隔离时长 = %v, want %v", got, quarantineBase)
+	}
+	if status := id.status(now); !status.Quarantined || status.Throttled != len(want)+1 {
+		t.Errorf("status = %+v", status)
+	}
+}
+
+// TestIdentityPool_Configure 测试重新配置时保留主身份
+func TestIdentityPool_Configure(t *testing.T) {
+	p := newTestPool(StrategyRoundRobin, "a")
+	primary := p.primary()
+	primary.touch(time.Now())
+
+	p.configure([]Identity{{Name: "b"}, {Name: "c"}}, "unknown")
+
+	status := p.status()
+	if status.Strategy != StrategyRoundRobin {
+		t.Errorf("未知策略应回退为轮询, got %s", status.Strategy)
+	}
+	if len(status.Identities) != 3 || status.Identities[0].Name != primaryIdentityName || !status.Identities[0].Primary {
+		t.Fatalf("identities = %+v", status.Identities)
+	}
+	if p.primary() != primary || status.Identities[0].Requests != 1 {
+		t.Errorf("主身份状态未保留: %+v", status.Identities[0])
+	}
+}
+
+// TestIdentity_CookieHeader 测试额外身份使用自己的登录凭据
+func TestIdentity_CookieHeader(t *testing.T) {
+	defer SetCredential(Credential{})
+	SetCredential(Credential{SESSDATA: "primary"})
+
+	id := &identity{Identity: Identity{Name: "a", Credential: Credential{SESSDATA: "extra"}}, buvid3: "b3", buvid4: "b4"}
+	got := id.cookieHeader()
+	if !strings.Contains(got, "SESSDATA=extra") || strings.Contains(got, "primary") {
+		t.Errorf("cookieHeader() = %s", got)
+	}
+
+	guest := &identity{Identity: Identity{Name: "guest"}, buvid3: "b3", buvid4: "b4"}
+	if got := guest.cookieHeader(); got != "buvid3=b3; buvid4=b4" {
+		t.Errorf("游客 cookieHeader() = %s", got)
+	}
+}
@@ -0,0 +1,117 @@
+// Package platform 提供直播间状态查询
+package platform
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// liveStatusResponse 用于解析批量直播间状态接口响应
+type liveStatusResponse struct {
+	Code    int           `json:"code"`
+	Message string        `json:"msg"`
+	Data    liveStatusMap `json:"data"`
+}
+
+// liveStatusMap 以 uid 为键的直播间状态
+// 所有 uid 均未开通直播间时接口返回空数组而非对象，需要兼容
+type liveStatusMap map[string]struct {
+	Title         string `json:"title"`
+	RoomID        int64  `json:"room_id"`
+	Online        int    `json:"online"`
+	LiveTime      int64  `json:"live_time"`
+	LiveStatus    int    `json:"live_status"`
+	AreaV2Name    string `json:"area_v2_name"`
+	AreaV2Parent  string `json:"area_v2_parent_name"`
+	Uname         string `json:"uname"`
+	CoverFromUser string `json:"cover_from_user"`
+	Keyframe      string `json:"keyframe"`
+}
+
+// UnmarshalJSON 兼容 data 为空数组的情况
+func (m *liveStatusMap) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '[' {
+		*m = liveStatusMap{}
+		return nil
+	}
+	type plain liveStatusMap
+	return json.Unmarshal(data, (*plain)(m))
+}
+
+// FetchLiveRooms 批量获取多个用户的直播间状态
+// names 为 mid 到显示名称的映射，非空时覆盖 API 返回的主播名称
+func (c *BilibiliClient) FetchLiveRooms(mids []string, names map[string]string) (map[string]models.LiveRoom, error) {
+	if len(mids) == 0 {
+		return make(map[string]models.LiveRoom), nil
+	}
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (map[string]models.LiveRoom, error) {
+		return c.fetchLiveRoomsOnce(mids, names)
+	})
+}
+
+func (c *BilibiliClient) fetchLiveRoomsOnce(mids []string, names map[string]string) (map[string]models.LiveRoom, error) {
+	rooms := make(map[string]models.LiveRoom, len(mids))
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	var apiResp liveStatusResponse
+	req := c.R().
+		SetHeader("Referer", "https://live.bilibili.com/").
+		SetHeader("Origin", "https://live.bilibili.com").
+		SetResult(&apiResp)
+	for _, mid := range mids {
+		req.QueryParam.Add("uids[]", mid)
+	}
+
+	resp, err := req.Get("https://api.live.bilibili.com/room/v1/Room/get_status_info_by_uids")
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	for _, mid := range mids {
+		room := models.LiveRoom{
+			Mid:       mid,
+			Author:    names[mid],
+			AuthorUrl: fmt.Sprintf("https://space.bilibili.com/%s", mid),
+		}
+
+		// 未开通直播间的用户不会出现在返回结果中
+		if r, ok := apiResp.Data[mid]; ok {
+			if room.Author == "" {
+				room.Author = r.Uname
+			}
+			cover := r.CoverFromUser
+			if cover == "" {
+				cover = r.Keyframe
+			}
+			room.RoomID = strconv.FormatInt(r.RoomID, 10)
+			room.Title = r.Title
+			room.CoverUrl = cover
+			room.Url = fmt.Sprintf("https://live.bilibili.com/%d", r.RoomID)
+			room.Area = r.AreaV2Name
+			room.ParentArea = r.AreaV2Parent
+			room.Online = r.Online
+			room.Status = r.LiveStatus
+			if r.LiveStatus == models.LiveStatusLive && r.LiveTime > 0 {
+				room.LiveSince = time.Unix(r.LiveTime, 0)
+			}
+		}
+
+		rooms[mid] = room
+	}
+
+	return rooms, nil
+}
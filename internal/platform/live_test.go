@@ -0,0 +1,39 @@
+// Package platform 直播间状态单元测试
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestLiveStatusResponse_Unmarshal 测试直播间状态响应解析
+func TestLiveStatusResponse_Unmarshal(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected int
+	}{
+		{
+			name:     "对象形式",
+			input:    `{"code":0,"msg":"success","data":{"946974":{"title":"测试","room_id":123,"live_status":1}}}`,
+			expected: 1,
+		},
+		{
+			name:     "空数组形式",
+			input:    `{"code":0,"msg":"success","data":[]}`,
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp liveStatusResponse
+			if err := json.Unmarshal([]byte(tt.input), &resp); err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if len(resp.Data) != tt.expected {
+				t.Errorf("got %d rooms, want %d", len(resp.Data), tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+// Package platform 提供热门、每周必看与分区排行榜获取功能
+package platform
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// popularPageSize 热门列表单页数量
+	popularPageSize = 20
+	// maxPopularPages 单次获取热门列表的最大翻页数
+	maxPopularPages = 5
+)
+
+// archiveInfo 热门、每周必看与排行榜接口共用的视频结构
+type archiveInfo struct {
+	Bvid     string `json:"bvid"`
+	Title    string `json:"title"`
+	Pic      string `json:"pic"`
+	Pubdate  int64  `json:"pubdate"`
+	Duration int    `json:"duration"`
+	Tid      int    `json:"tid"`
+	Owner    struct {
+		Mid  int64  `json:"mid"`
+		Name string `json:"name"`
+	} `json:"owner"`
+	Stat struct {
+		View int `json:"view"`
+	} `json:"stat"`
+}
+
+// archiveListResponse 用于解析返回视频列表的接口响应
+type archiveListResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		List   []archiveInfo `json:"list"`
+		NoMore bool          `json:"no_more"`
+	} `json:"data"`
+}
+
+// weeklySeriesResponse 用于解析每周必看期数列表接口响应
+type weeklySeriesResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		List []struct {
+			Number  int    `json:"number"`
+			Subject string `json:"subject"`
+		} `json:"list"`
+	} `json:"data"`
+}
+
+// FetchPopularVideos 获取综合热门视频（按热门顺序）
+func (c *BilibiliClient) FetchPopularVideos(limit int) (models.VideoList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchPopularVideosOnce(limit)
+	})
+}
+
+func (c *BilibiliClient) fetchPopularVideosOnce(limit int) (models.VideoList, error) {
+	videos := make(models.VideoList, 0, limit)
+	for page := 1; page <= maxPopularPages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		var apiResp archiveListResponse
+		err := c.getArchiveList("https://api.bilibili.com/x/web-interface/popular", map[string]string{
+			"pn": strconv.Itoa(page),
+			"ps": strconv.Itoa(popularPageSize),
+		}, &apiResp)
+		if err != nil {
+			return nil, err
+		}
+
+		videos = appendArchives(videos, apiResp.Data.List, limit)
+		if apiResp.Data.NoMore || len(apiResp.Data.List) == 0 {
+			break
+		}
+	}
+
+	return videos, nil
+}
+
+// FetchWeeklyVideos 获取每周必看视频
+// number 为期数，小于等于 0 时获取最新一期
+func (c *BilibiliClient) FetchWeeklyVideos(number int, limit int) (models.VideoList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchWeeklyVideosOnce(number, limit)
+	})
+}
+
+func (c *BilibiliClient) fetchWeeklyVideosOnce(number int, limit int) (models.VideoList, error) {
+	if number <= 0 {
+		latest, err := c.latestWeeklyNumber()
+		if err != nil {
+			return nil, err
+		}
+		number = latest
+	}
+
+	var apiResp archiveListResponse
+	err := c.getArchiveList("https://api.bilibili.com/x/web-interface/popular/series/one", map[string]string{
+		"number": strconv.Itoa(number),
+	}, &apiResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return appendArchives(make(models.VideoList, 0, limit), apiResp.Data.List, limit), nil
+}
+
+// latestWeeklyNumber 获取每周必看的最新期数
+func (c *BilibiliClient) latestWeeklyNumber() (int, error) {
+	var apiResp weeklySeriesResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/v/popular/weekly").
+		SetResult(&apiResp).
+		Get("https://api.bilibili.com/x/web-interface/popular/series/list")
+
+	if err != nil {
+		return 0, err
+	}
+
+	if !resp.IsSuccess() {
+		return 0, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return 0, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	if len(apiResp.Data.List) == 0 {
+		return 0, fmt.Errorf("每周必看期数列表为空")
+	}
+
+	return apiResp.Data.List[0].Number, nil
+}
+
+// FetchRankingVideos 获取分区排行榜视频（按排名顺序）
+// rid 为分区 ID，0 表示全站
+func (c *BilibiliClient) FetchRankingVideos(rid int, limit int) (models.VideoList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchRankingVideosOnce(rid, limit)
+	})
+}
+
+func (c *BilibiliClient) fetchRankingVideosOnce(rid int, limit int) (models.VideoList, error) {
+	params := url.Values{}
+	params.Set("rid", strconv.Itoa(rid))
+	params.Set("type", "all")
+
+	// WBI 签名
+	signedParams, err := c.wbiKeys.Sign(params)
+	if err != nil {
+		return nil, fmt.Errorf("WBI 签名失败: %w", err)
+	}
+
+	query := make(map[string]string, len(signedParams))
+	for k := range signedParams {
+		query[k] = signedParams.Get(k)
+	}
+
+	var apiResp archiveListResponse
+	if err := c.getArchiveList("https://api.bilibili.com/x/web-interface/ranking/v2", query, &apiResp); err != nil {
+		return nil, err
+	}
+
+	return appendArchives(make(models.VideoList, 0, limit), apiResp.Data.List, limit), nil
+}
+
+// getArchiveList 请求返回视频列表的接口并校验响应
+func (c *BilibiliClient) getArchiveList(endpoint string, query map[string]string, result *archiveListResponse) error {
+	if err := c.ensureBuvid(); err != nil {
+		return err
+	}
+
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/v/popular/all").
+		SetQueryParams(query).
+		SetResult(result).
+		Get(endpoint)
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if result.Code != 0 {
+		return fmt.Errorf("API 错误: code=%d, message=%s", result.Code, result.Message)
+	}
+
+	return nil
+}
+
+// appendArchives 将接口返回的视频转换后追加到列表，最多追加到 limit 条
+func appendArchives(videos models.VideoList, archives []archiveInfo, limit int) models.VideoList {
+	for _, a := range archives {
+		if len(videos) >= limit {
+			break
+		}
+		videos = append(videos, models.Video{
+			Title:        a.Title,
+			ThumbnailUrl: a.Pic,
+			Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", a.Bvid),
+			Author:       a.Owner.Name,
+			AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%d", a.Owner.Mid),
+			AuthorMid:    strconv.FormatInt(a.Owner.Mid, 10),
+			TimePosted:   time.Unix(a.Pubdate, 0),
+			Duration:     formatDuration(a.Duration),
+			PlayCount:    a.Stat.View,
+			Bvid:         a.Bvid,
+			Tid:          a.Tid,
+		})
+	}
+	return videos
+}
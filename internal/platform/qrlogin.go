@@ -0,0 +1,124 @@
+// Package platform 提供网页端扫码登录功能
+package platform
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"glance-bilibili/internal/models"
+)
+
+// 扫码登录轮询接口的状态码
+const (
+	qrCodeConfirmed = 0
+	qrCodeExpired   = 86038
+	qrCodeScanned   = 86090
+	qrCodeWaiting   = 86101
+)
+
+// qrGenerateResponse 用于解析二维码申请接口响应
+type qrGenerateResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		URL       string `json:"url"`
+		QRCodeKey string `json:"qrcode_key"`
+	} `json:"data"`
+}
+
+// qrPollResponse 用于解析扫码登录轮询接口响应
+type qrPollResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		URL          string `json:"url"`
+		RefreshToken string `json:"refresh_token"`
+		Code         int    `json:"code"`
+		Message      string `json:"message"`
+	} `json:"data"`
+}
+
+// GenerateQRLogin 申请网页端登录二维码，有效期约 180 秒
+func (c *BilibiliClient) GenerateQRLogin() (models.QRLogin, error) {
+	var apiResp qrGenerateResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://passport.bilibili.com/login").
+		SetResult(&apiResp).
+		Get("https://passport.bilibili.com/x/passport-login/web/qrcode/generate")
+
+	if err != nil {
+		return models.QRLogin{}, err
+	}
+
+	if !resp.IsSuccess() {
+		return models.QRLogin{}, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return models.QRLogin{}, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	return models.QRLogin{URL: apiResp.Data.URL, Key: apiResp.Data.QRCodeKey}, nil
+}
+
+// PollQRLogin 查询扫码登录状态，确认登录后返回登录凭据
+func (c *BilibiliClient) PollQRLogin(key string) (models.QRLoginState, Credential, error) {
+	var apiResp qrPollResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://passport.bilibili.com/login").
+		SetQueryParam("qrcode_key", key).
+		SetResult(&apiResp).
+		Get("https://passport.bilibili.com/x/passport-login/web/qrcode/poll")
+
+	if err != nil {
+		return "", Credential{}, err
+	}
+
+	if !resp.IsSuccess() {
+		return "", Credential{}, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return "", Credential{}, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	return parseQRPoll(apiResp, resp.Cookies())
+}
+
+// parseQRPoll 解析轮询结果
+// 登录凭据优先取自响应的 Set-Cookie，缺失时从跳转链接的查询参数中补全
+func parseQRPoll(apiResp qrPollResponse, cookies []*http.Cookie) (models.QRLoginState, Credential, error) {
+	switch apiResp.Data.Code {
+	case qrCodeWaiting:
+		return models.QRLoginWaiting, Credential{}, nil
+	case qrCodeScanned:
+		return models.QRLoginScanned, Credential{}, nil
+	case qrCodeExpired:
+		return models.QRLoginExpired, Credential{}, nil
+	case qrCodeConfirmed:
+	default:
+		return "", Credential{}, fmt.Errorf("扫码登录失败: code=%d, message=%s", apiResp.Data.Code, apiResp.Data.Message)
+	}
+
+	cr := Credential{RefreshToken: apiResp.Data.RefreshToken}
+	cr.applyCookies(cookies)
+
+	if u, err := url.Parse(apiResp.Data.URL); err == nil {
+		query := u.Query()
+		if cr.SESSDATA == "" {
+			cr.SESSDATA = query.Get("SESSDATA")
+		}
+		if cr.BiliJct == "" {
+			cr.BiliJct = query.Get("bili_jct")
+		}
+		if cr.DedeUserID == "" {
+			cr.DedeUserID = query.Get("DedeUserID")
+		}
+	}
+
+	if cr.IsZero() {
+		return "", Credential{}, fmt.Errorf("扫码登录成功但未获取到 SESSDATA")
+	}
+	return models.QRLoginConfirmed, cr, nil
+}
@@ -0,0 +1,132 @@
+// Package platform 提供关键词搜索功能
+package platform
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// searchPageSize 搜索接口单页数量（固定值）
+	searchPageSize = 20
+	// maxSearchPages 单次搜索的最大翻页数
+	maxSearchPages = 3
+)
+
+// htmlTagPattern 匹配搜索结果标题中的高亮标签，如 <em class="keyword">
+var htmlTagPattern = regexp.MustCompile(`<[^>]+>`)
+
+// searchResponse 用于解析分类搜索接口响应
+type searchResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		NumPages int `json:"numPages"`
+		Result   []struct {
+			Type     string `json:"type"`
+			Bvid     string `json:"bvid"`
+			Title    string `json:"title"`
+			Pic      string `json:"pic"`
+			Author   string `json:"author"`
+			Mid      int64  `json:"mid"`
+			Pubdate  int64  `json:"pubdate"`
+			Duration string `json:"duration"`
+			Play     int    `json:"play"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// SearchVideos 按关键词搜索视频，结果按发布时间倒序并按 BV 号去重
+func (c *BilibiliClient) SearchVideos(keyword string, limit int) (models.VideoList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.searchVideosOnce(keyword, limit)
+	})
+}
+
+func (c *BilibiliClient) searchVideosOnce(keyword string, limit int) (models.VideoList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	videos := make(models.VideoList, 0, limit)
+	seen := make(map[string]bool, limit)
+	for page := 1; page <= maxSearchPages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		params := url.Values{}
+		params.Set("search_type", "video")
+		params.Set("keyword", keyword)
+		params.Set("order", "pubdate")
+		params.Set("page", strconv.Itoa(page))
+
+		// WBI 签名
+		signedParams, err := c.wbiKeys.Sign(params)
+		if err != nil {
+			return nil, fmt.Errorf("WBI 签名失败: %w", err)
+		}
+
+		apiURL := "https://api.bilibili.com/x/web-interface/wbi/search/type?" + signedParams.Encode()
+
+		var apiResp searchResponse
+		resp, err := c.R().
+			SetHeader("Referer", "https://search.bilibili.com/all?keyword="+url.QueryEscape(keyword)).
+			SetHeader("Origin", "https://search.bilibili.com").
+			SetResult(&apiResp).
+			Get(apiURL)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, r := range apiResp.Data.Result {
+			if r.Type != "video" || r.Bvid == "" || seen[r.Bvid] {
+				continue
+			}
+			seen[r.Bvid] = true
+
+			videos = append(videos, models.Video{
+				Title:        cleanSearchTitle(r.Title),
+				ThumbnailUrl: normalizeJumpUrl(r.Pic),
+				Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", r.Bvid),
+				Author:       r.Author,
+				AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%d", r.Mid),
+				AuthorMid:    strconv.FormatInt(r.Mid, 10),
+				TimePosted:   time.Unix(r.Pubdate, 0),
+				Duration:     r.Duration,
+				PlayCount:    r.Play,
+				Bvid:         r.Bvid,
+			})
+			if len(videos) >= limit {
+				break
+			}
+		}
+
+		if page >= apiResp.Data.NumPages || len(apiResp.Data.Result) < searchPageSize {
+			break
+		}
+	}
+
+	return videos, nil
+}
+
+// cleanSearchTitle 去除搜索结果标题中的高亮标签并反转义 HTML 实体
+func cleanSearchTitle(title string) string {
+	return strings.TrimSpace(html.UnescapeString(htmlTagPattern.ReplaceAllString(title, "")))
+}
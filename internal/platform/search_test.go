@@ -0,0 +1,37 @@
+// Package platform 关键词搜索单元测试
+package platform
+
+import "testing"
+
+// TestCleanSearchTitle 测试搜索结果标题清洗
+func TestCleanSearchTitle(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "去除高亮标签",
+			input:    `<em class="keyword">Vision</em> Pro 上手`,
+			expected: "Vision Pro 上手",
+		},
+		{
+			name:     "反转义 HTML 实体",
+			input:    `A &amp; B &quot;测评&quot;`,
+			expected: `A & B "测评"`,
+		},
+		{
+			name:     "普通标题不受影响",
+			input:    "普通标题",
+			expected: "普通标题",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanSearchTitle(tt.input); got != tt.expected {
+				t.Errorf("cleanSearchTitle() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+// Package platform 提供合集与系列获取功能
+package platform
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// seasonPageSize 合集/系列接口单页数量
+	seasonPageSize = 30
+	// maxSeasonPages 单次获取合集/系列的最大翻页数
+	maxSeasonPages = 5
+)
+
+// seasonArchive 合集与系列接口共用的视频结构
+type seasonArchive struct {
+	Bvid     string `json:"bvid"`
+	Title    string `json:"title"`
+	Pic      string `json:"pic"`
+	Pubdate  int64  `json:"pubdate"`
+	Duration int    `json:"duration"`
+	Stat     struct {
+		View int `json:"view"`
+	} `json:"stat"`
+}
+
+// seasonResponse 用于解析合集视频列表接口响应
+type seasonResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Archives []seasonArchive `json:"archives"`
+		Meta     struct {
+			Name string `json:"name"`
+		} `json:"meta"`
+		Page struct {
+			PageNum  int `json:"page_num"`
+			PageSize int `json:"page_size"`
+			Total    int `json:"total"`
+		} `json:"page"`
+	} `json:"data"`
+}
+
+// seriesResponse 用于解析系列视频列表接口响应
+type seriesResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Archives []seasonArchive `json:"archives"`
+		Page     struct {
+			Num   int `json:"num"`
+			Size  int `json:"size"`
+			Total int `json:"total"`
+		} `json:"page"`
+	} `json:"data"`
+}
+
+// seriesMetaResponse 用于解析系列信息接口响应
+type seriesMetaResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Meta struct {
+			Name string `json:"name"`
+		} `json:"meta"`
+	} `json:"data"`
+}
+
+// FetchSeasonVideos 获取 UP 主某个合集中的视频（最新在前）
+// authorOverride 如果非空，则用它作为作者名称，否则使用合集名称
+func (c *BilibiliClient) FetchSeasonVideos(mid, seasonID string, limit int, authorOverride string) (models.VideoList, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchSeasonVideosOnce(mid, seasonID, limit, authorOverride)
+	})
+}
+
+func (c *BilibiliClient) fetchSeasonVideosOnce(mid, seasonID string, limit int, authorOverride string) (models.VideoList, error) {
+	videos := make(models.VideoList, 0, limit)
+	for page := 1; page <= maxSeasonPages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		params := url.Values{}
+		params.Set("mid", mid)
+		params.Set("season_id", seasonID)
+		params.Set("sort_reverse", "true")
+		params.Set("page_num", strconv.Itoa(page))
+		params.Set("page_size", strconv.Itoa(seasonPageSize))
+
+		var apiResp seasonResponse
+		if err := c.getSpaceSigned(mid, "https://api.bilibili.com/x/polymer/web-space/seasons_archives_list", params, &apiResp); err != nil {
+			return nil, err
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		author := authorOverride
+		if author == "" {
+			author = apiResp.Data.Meta.Name
+		}
+		videos = appendSeasonArchives(videos, apiResp.Data.Archives, mid, author, limit)
+
+		if page*seasonPageSize >= apiResp.Data.Page.Total {
+			break
+		}
+	}
+
+	return videos, nil
+}
+
+// FetchSeriesVideos 获取 UP 主某个系列中的视频（最新在前）
+// authorOverride 如果非空，则用它作为作者名称，否则使用系列名称，系列名称获取失败时使用 UP 主昵称
+func (c *BilibiliClient) FetchSeriesVideos(mid, seriesID string, limit int, authorOverride string) (models.VideoList, error) {
+	return withRiskControlRetry(c, mid, func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchSeriesVideosOnce(mid, seriesID, limit, authorOverride)
+	})
+}
+
+func (c *BilibiliClient) fetchSeriesVideosOnce(mid, seriesID string, limit int, authorOverride string) (models.VideoList, error) {
+	// 系列视频列表接口不返回系列名称，需单独获取
+	author := authorOverride
+	if author == "" {
+		author = c.seriesAuthor(mid, seriesID)
+	}
+
+	videos := make(models.VideoList, 0, limit)
+	for page := 1; page <= maxSeasonPages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		params := url.Values{}
+		params.Set("mid", mid)
+		params.Set("series_id", seriesID)
+		params.Set("only_normal", "true")
+		params.Set("sort", "desc")
+		params.Set("pn", strconv.Itoa(page))
+		params.Set("ps", strconv.Itoa(seasonPageSize))
+
+		var apiResp seriesResponse
+		if err := c.getSpaceSigned(mid, "https://api.bilibili.com/x/series/archives", params, &apiResp); err != nil {
+			return nil, err
+		}
+
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		videos = appendSeasonArchives(videos, apiResp.Data.Archives, mid, author, limit)
+
+		if page*seasonPageSize >= apiResp.Data.Page.Total {
+			break
+		}
+	}
+
+	return videos, nil
+}
+
+// seriesAuthor 返回系列视频的作者名称：优先使用系列名称，获取失败时使用 UP 主昵称
+// 两者都获取失败时返回空字符串，不影响视频列表本身
+func (c *BilibiliClient) seriesAuthor(mid, seriesID string) string {
+	name, err := c.fetchSeriesName(seriesID)
+	if err == nil && name != "" {
+		return name
+	}
+	logger.Warnw("获取系列名称失败，使用 UP 主昵称",
+		"up_mid", mid,
+		"series_id", seriesID,
+		"error", err,
+	)
+
+	profile, err := c.fetchChannelProfileOnce(mid, "")
+	if err != nil {
+		logger.Warnw("获取 UP 主昵称失败",
+			"up_mid", mid,
+			"error", err,
+		)
+		return ""
+	}
+	return profile.Name
+}
+
+// fetchSeriesName 获取系列名称
+func (c *BilibiliClient) fetchSeriesName(seriesID string) (string, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return "", err
+	}
+
+	var apiResp seriesMetaResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/").
+		SetQueryParam("series_id", seriesID).
+		SetResult(&apiResp).
+		Get("https://api.bilibili.com/x/series/series")
+
+	if err != nil {
+		return "", err
+	}
+
+	if !resp.IsSuccess() {
+		return "", fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return "", fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	return apiResp.Data.Meta.Name, nil
+}
+
+// getSpaceSigned 以 UP 主空间页的身份发起 WBI 签名请求
+func (c *BilibiliClient) getSpaceSigned(mid, endpoint string, params url.Values, result interface{}) error {
+	if err := c.ensureBuvid(); err != nil {
+		return err
+	}
+
+	// 添加 dm 参数
+	for k, v := range getDmParams() {
+		params[k] = v
+	}
+
+	// WBI 签名
+	signedParams, err := c.wbiKeys.Sign(params)
+	if err != nil {
+		return fmt.Errorf("WBI 签名失败: %w", err)
+	}
+
+	resp, err := c.R().
+		SetHeader("Referer", "https://space.bilibili.com/"+mid).
+		SetHeader("Origin", "https://space.bilibili.com").
+		SetResult(result).
+		Get(endpoint + "?" + signedParams.Encode())
+
+	if err != nil {
+		return err
+	}
+
+	if !resp.IsSuccess() {
+		return fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	return nil
+}
+
+// appendSeasonArchives 将合集/系列中的视频转换后追加到列表，最多追加到 limit 条
+func appendSeasonArchives(videos models.VideoList, archives []seasonArchive, mid, author string, limit int) models.VideoList {
+	for _, a := range archives {
+		if len(videos) >= limit {
+			break
+		}
+		videos = append(videos, models.Video{
+			Title:        a.Title,
+			ThumbnailUrl: a.Pic,
+			Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", a.Bvid),
+			Author:       author,
+			AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%s", mid),
+			AuthorMid:    mid,
+			TimePosted:   time.Unix(a.Pubdate, 0),
+			Duration:     formatDuration(a.Duration),
+			PlayCount:    a.Stat.View,
+			Bvid:         a.Bvid,
+		})
+	}
+	return videos
+}
@@ -0,0 +1,131 @@
+// Package platform 提供关注时间线（登录用户关注的 UP 主的视频动态）获取功能
+package platform
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// maxTimelinePages 单次获取关注时间线的最大翻页数
+const maxTimelinePages = 5
+
+// FetchFollowingTimeline 获取登录用户关注的 UP 主的视频投稿时间线（最新在前）
+// 需要配置登录凭据，未登录时接口返回 -101
+func (c *BilibiliClient) FetchFollowingTimeline(limit int) (models.VideoList, error) {
+	if credentialCookie() == "" {
+		return nil, fmt.Errorf("关注时间线需要配置登录凭据")
+	}
+	// 关注时间线属于登录用户本人，固定使用主身份
+	return withRiskControlRetry(c.primaryClient(), "", func(c *BilibiliClient) (models.VideoList, error) {
+		return c.fetchFollowingTimelineOnce(limit)
+	})
+}
+
+func (c *BilibiliClient) fetchFollowingTimelineOnce(limit int) (models.VideoList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	videos := make(models.VideoList, 0, limit)
+	seen := make(map[string]bool, limit)
+	offset := ""
+	for page := 1; page <= maxTimelinePages && len(videos) < limit; page++ {
+		if page > 1 {
+			time.Sleep(pageDelay())
+		}
+
+		params := url.Values{}
+		params.Set("type", "video")
+		params.Set("page", strconv.Itoa(page))
+		params.Set("offset", offset)
+		params.Set("features", "itemOpusStyle")
+
+		var apiResp dynamicResponse
+		resp, err := c.R().
+			SetHeader("Referer", "https://t.bilibili.com/").
+			SetHeader("Origin", "https://t.bilibili.com").
+			SetResult(&apiResp).
+			Get("https://api.bilibili.com/x/polymer/web-dynamic/v1/feed/all?" + params.Encode())
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !resp.IsSuccess() {
+			return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+		}
+
+		if apiResp.Code == -101 {
+			return nil, fmt.Errorf("登录凭据无效或已过期，无法获取关注时间线")
+		}
+		if apiResp.Code != 0 {
+			return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+		}
+
+		for _, raw := range apiResp.Data.Items {
+			video, ok := timelineVideo(raw)
+			if !ok || seen[video.Bvid] {
+				continue
+			}
+			seen[video.Bvid] = true
+			videos = append(videos, video)
+			if len(videos) >= limit {
+				break
+			}
+		}
+
+		if !apiResp.Data.HasMore || apiResp.Data.Offset == "" {
+			break
+		}
+		offset = apiResp.Data.Offset
+	}
+
+	return videos, nil
+}
+
+// timelineVideo 将时间线中的视频动态转换为视频，非视频动态返回 false
+func timelineVideo(raw dynamicItem) (models.Video, bool) {
+	major := raw.Modules.Dynamic.Major
+	if major == nil || major.Archive == nil || major.Archive.Bvid == "" {
+		return models.Video{}, false
+	}
+
+	author := raw.Modules.Author
+	archive := major.Archive
+	return models.Video{
+		Title:        archive.Title,
+		ThumbnailUrl: archive.Cover,
+		Url:          fmt.Sprintf("https://www.bilibili.com/video/%s", archive.Bvid),
+		Author:       author.Name,
+		AuthorUrl:    fmt.Sprintf("https://space.bilibili.com/%d", author.Mid),
+		AuthorMid:    strconv.FormatInt(author.Mid, 10),
+		AuthorFace:   author.Face,
+		TimePosted:   time.Unix(author.PubTs, 0),
+		Duration:     archive.DurationText,
+		PlayCount:    parseCountText(archive.Stat.Play),
+		Bvid:         archive.Bvid,
+		Description:  archive.Desc,
+	}, true
+}
+
+// parseCountText 解析动态接口中的数量文本，如 "3456"、"1.2万"、"1.5亿"，无法解析时返回 0
+func parseCountText(s string) int {
+	s = strings.TrimSpace(s)
+	multiplier := 1.0
+	if rest, ok := strings.CutSuffix(s, "万"); ok {
+		s, multiplier = rest, 1e4
+	} else if rest, ok := strings.CutSuffix(s, "亿"); ok {
+		s, multiplier = rest, 1e8
+	}
+
+	n, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0
+	}
+	return int(n * multiplier)
+}
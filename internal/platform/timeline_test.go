@@ -0,0 +1,83 @@
+// Package platform 关注时间线单元测试
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestTimelineVideo 测试时间线视频动态的转换
+func TestTimelineVideo(t *testing.T) {
+	tests := []struct {
+		name   string
+		raw    string
+		wantOK bool
+		bvid   string
+		play   int
+	}{
+		{
+			name: "视频动态",
+			raw: `{"type": "DYNAMIC_TYPE_AV", "modules": {
+				"module_author": {"mid": 946974, "name": "UP", "face": "f.jpg", "pub_ts": 1700000000},
+				"module_dynamic": {"major": {"archive": {"bvid": "BV1xx", "title": "标题", "cover": "c.jpg", "duration_text": "10:00", "stat": {"play": "1.2万"}}}}
+			}}`,
+			wantOK: true,
+			bvid:   "BV1xx",
+			play:   12000,
+		},
+		{
+			name: "非视频动态",
+			raw: `{"type": "DYNAMIC_TYPE_DRAW", "modules": {
+				"module_author": {"mid": 1, "name": "UP", "pub_ts": 1700000000},
+				"module_dynamic": {"major": {"draw": {"items": [{"src": "a.jpg"}]}}}
+			}}`,
+		},
+		{
+			name: "缺少 major",
+			raw:  `{"type": "DYNAMIC_TYPE_AV", "modules": {"module_dynamic": {"major": null}}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var item dynamicItem
+			if err := json.Unmarshal([]byte(tt.raw), &item); err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+
+			video, ok := timelineVideo(item)
+			if ok != tt.wantOK {
+				t.Fatalf("timelineVideo() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if video.Bvid != tt.bvid || video.PlayCount != tt.play {
+				t.Errorf("timelineVideo() = %+v", video)
+			}
+			if video.AuthorMid != "946974" || video.AuthorFace != "f.jpg" || video.TimePosted.Unix() != 1700000000 {
+				t.Errorf("作者信息解析错误: %+v", video)
+			}
+		})
+	}
+}
+
+// TestParseCountText 测试数量文本解析
+func TestParseCountText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected int
+	}{
+		{"3456", 3456},
+		{"1.2万", 12000},
+		{"1.5亿", 150000000},
+		{"", 0},
+		{"-", 0},
+	}
+
+	for _, tt := range tests {
+		if got := parseCountText(tt.input); got != tt.expected {
+			t.Errorf("parseCountText(%q) = %d, want %d", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Package platform 提供用户搜索与短链接展开功能
+package platform
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"glance-bilibili/internal/models"
+)
+
+// userSearchResponse 用于解析用户搜索接口响应
+type userSearchResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		Result []struct {
+			Mid   int64  `json:"mid"`
+			Uname string `json:"uname"`
+			Usign string `json:"usign"`
+			Upic  string `json:"upic"`
+			Fans  int    `json:"fans"`
+			Level int    `json:"level"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// SearchUsers 按名称搜索用户（第一页结果）
+func (c *BilibiliClient) SearchUsers(keyword string) (models.ChannelList, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.ChannelList, error) {
+		return c.searchUsersOnce(keyword)
+	})
+}
+
+func (c *BilibiliClient) searchUsersOnce(keyword string) (models.ChannelList, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("search_type", "bili_user")
+	params.Set("keyword", keyword)
+	params.Set("page", "1")
+
+	// WBI 签名
+	signedParams, err := c.wbiKeys.Sign(params)
+	if err != nil {
+		return nil, fmt.Errorf("WBI 签名失败: %w", err)
+	}
+
+	apiURL := "https://api.bilibili.com/x/web-interface/wbi/search/type?" + signedParams.Encode()
+
+	var apiResp userSearchResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://search.bilibili.com/upuser?keyword="+url.QueryEscape(keyword)).
+		SetHeader("Origin", "https://search.bilibili.com").
+		SetResult(&apiResp).
+		Get(apiURL)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !resp.IsSuccess() {
+		return nil, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return nil, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	users := make(models.ChannelList, 0, len(apiResp.Data.Result))
+	for _, u := range apiResp.Data.Result {
+		mid := strconv.FormatInt(u.Mid, 10)
+		users = append(users, models.Channel{
+			Mid:      mid,
+			Name:     cleanSearchTitle(u.Uname),
+			Url:      fmt.Sprintf("https://space.bilibili.com/%s", mid),
+			Face:     normalizeJumpUrl(u.Upic),
+			Sign:     u.Usign,
+			Level:    u.Level,
+			Follower: u.Fans,
+		})
+	}
+	return users, nil
+}
+
+// ExpandShortLink 展开 b23.tv 短链接，返回跳转后的完整链接
+func (c *BilibiliClient) ExpandShortLink(link string) (string, error) {
+	resp, err := GetRestyClient().R().
+		SetHeader("Accept", "text/html").
+		Get(link)
+
+	if err != nil {
+		return "", err
+	}
+
+	if !resp.IsSuccess() {
+		return "", fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	// 跟随跳转后的最终请求地址即为展开后的链接
+	return resp.RawResponse.Request.URL.String(), nil
+}
@@ -0,0 +1,106 @@
+// Package platform 提供视频详情获取功能
+package platform
+
+import (
+	"fmt"
+	"net/url"
+
+	"glance-bilibili/internal/models"
+)
+
+// viewDetailResponse 用于解析视频详情接口响应
+type viewDetailResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    struct {
+		View struct {
+			Bvid string `json:"bvid"`
+			Desc string `json:"desc"`
+			Stat struct {
+				View     int `json:"view"`
+				Danmaku  int `json:"danmaku"`
+				Reply    int `json:"reply"`
+				Favorite int `json:"favorite"`
+				Coin     int `json:"coin"`
+				Share    int `json:"share"`
+				Like     int `json:"like"`
+			} `json:"stat"`
+		} `json:"View"`
+		Tags []struct {
+			TagName string `json:"tag_name"`
+		} `json:"Tags"`
+	} `json:"data"`
+}
+
+// FetchVideoDetail 获取单个视频的互动数据、简介与标签
+func (c *BilibiliClient) FetchVideoDetail(bvid string) (models.VideoDetail, error) {
+	return withRiskControlRetry(c, "", func(c *BilibiliClient) (models.VideoDetail, error) {
+		return c.fetchVideoDetailOnce(bvid)
+	})
+}
+
+func (c *BilibiliClient) fetchVideoDetailOnce(bvid string) (models.VideoDetail, error) {
+	if err := c.ensureBuvid(); err != nil {
+		return models.VideoDetail{}, err
+	}
+
+	params := url.Values{}
+	params.Set("bvid", bvid)
+
+	// WBI 签名
+	signedParams, err := c.wbiKeys.Sign(params)
+	if err != nil {
+		return models.VideoDetail{}, fmt.Errorf("WBI 签名失败: %w", err)
+	}
+
+	apiURL := "https://api.bilibili.com/x/web-interface/wbi/view/detail?" + signedParams.Encode()
+
+	var apiResp viewDetailResponse
+	resp, err := c.R().
+		SetHeader("Referer", "https://www.bilibili.com/video/"+bvid).
+		SetHeader("Origin", "https://www.bilibili.com").
+		SetResult(&apiResp).
+		Get(apiURL)
+
+	if err != nil {
+		return models.VideoDetail{}, err
+	}
+
+	if !resp.IsSuccess() {
+		return models.VideoDetail{}, fmt.Errorf("HTTP 错误: %d", resp.StatusCode())
+	}
+
+	if apiResp.Code != 0 {
+		return models.VideoDetail{}, fmt.Errorf("API 错误: code=%d, message=%s", apiResp.Code, apiResp.Message)
+	}
+
+	return parseViewDetail(bvid, &apiResp), nil
+}
+
+// parseViewDetail 将视频详情接口响应转换为 VideoDetail
+func parseViewDetail(bvid string, apiResp *viewDetailResponse) models.VideoDetail {
+	view := apiResp.Data.View
+	detail := models.VideoDetail{
+		Bvid:      bvid,
+		PlayCount: view.Stat.View,
+		Stats: models.VideoStats{
+			Like:     view.Stat.Like,
+			Coin:     view.Stat.Coin,
+			Favorite: view.Stat.Favorite,
+			Share:    view.Stat.Share,
+			Danmaku:  view.Stat.Danmaku,
+			Reply:    view.Stat.Reply,
+		},
+		Description: view.Desc,
+	}
+	// 简介为 "-" 表示 UP 主未填写
+	if detail.Description == "-" {
+		detail.Description = ""
+	}
+	for _, t := range apiResp.Data.Tags {
+		if t.TagName != "" {
+			detail.Tags = append(detail.Tags, t.TagName)
+		}
+	}
+	return detail
+}
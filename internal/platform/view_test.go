@@ -0,0 +1,44 @@
+// Package platform 视频详情单元测试
+package platform
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestParseViewDetail 测试视频详情解析
+func TestParseViewDetail(t *testing.T) {
+	body := `{
+		"code": 0,
+		"message": "0",
+		"data": {
+			"View": {
+				"bvid": "BV1xx411c7mD",
+				"desc": "-",
+				"stat": {"view": 1000, "danmaku": 20, "reply": 30, "favorite": 40, "coin": 50, "share": 60, "like": 70}
+			},
+			"Tags": [{"tag_name": "科技"}, {"tag_name": ""}, {"tag_name": "数码"}]
+		}
+	}`
+
+	var apiResp viewDetailResponse
+	if err := json.Unmarshal([]byte(body), &apiResp); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	detail := parseViewDetail("BV1xx411c7mD", &apiResp)
+
+	if detail.PlayCount != 1000 {
+		t.Errorf("PlayCount = %d, want 1000", detail.PlayCount)
+	}
+	if detail.Stats.Like != 70 || detail.Stats.Coin != 50 || detail.Stats.Favorite != 40 ||
+		detail.Stats.Share != 60 || detail.Stats.Danmaku != 20 || detail.Stats.Reply != 30 {
+		t.Errorf("Stats = %+v", detail.Stats)
+	}
+	if detail.Description != "" {
+		t.Errorf("Description = %q, want empty for \"-\"", detail.Description)
+	}
+	if len(detail.Tags) != 2 || detail.Tags[0] != "科技" || detail.Tags[1] != "数码" {
+		t.Errorf("Tags = %v, want [科技 数码]", detail.Tags)
+	}
+}
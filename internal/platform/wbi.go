@@ -36,7 +36,10 @@ var wbiKeys = &WbiKeys{}
 type navResponse struct {
 	Code int `json:"code"`
 	Data struct {
-		WbiImg struct {
+		IsLogin bool   `json:"isLogin"`
+		Mid     int64  `json:"mid"`
+		Uname   string `json:"uname"`
+		WbiImg  struct {
 			ImgUrl string `json:"img_url"`
 			SubUrl string `json:"sub_url"`
 		} `json:"wbi_img"`
@@ -64,6 +67,7 @@ func (wk *WbiKeys) Update() error {
 	var nav navResponse
 	resp, err := client.R().
 		SetHeader("Referer", "https://www.bilibili.com/").
+		SetHeader("Cookie", credentialCookie()).
 		SetResult(&nav).
 		Get("https://api.bilibili.com/x/web-interface/nav")
 
@@ -79,6 +83,9 @@ func (wk *WbiKeys) Update() error {
 		return fmt.Errorf("API 返回错误码: %d", nav.Code)
 	}
 
+	// nav 接口同时返回登录状态，-101 表示未登录
+	updateLoginStatus(nav.Data.IsLogin, nav.Data.Mid, nav.Data.Uname)
+
 	imgUrl := nav.Data.WbiImg.ImgUrl
 	subUrl := nav.Data.WbiImg.SubUrl
 	if imgUrl == "" || subUrl == "" {
@@ -0,0 +1,157 @@
+// Package service 提供专栏文章获取
+package service
+
+import (
+	"sync"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// articleCacheEntry 专栏文章缓存条目
+type articleCacheEntry struct {
+	articles  models.ArticleList
+	depth     int // 获取时请求的数量，请求更多数据时缓存视为无效
+	updatedAt time.Time
+}
+
+func (s *VideoService) getCachedArticles(mid string, limit int, cacheTTLSeconds int) (models.ArticleList, bool) {
+	s.mu.RLock()
+	entry, exists := s.articleCache[mid]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	// 缓存存在但已过期或数量不足，仍返回旧数据供降级兜底使用
+	if time.Since(entry.updatedAt) >= time.Duration(cacheTTLSeconds)*time.Second || entry.depth < limit {
+		return entry.articles, false
+	}
+
+	return entry.articles, true
+}
+
+func (s *VideoService) setCachedArticles(mid string, articles models.ArticleList, depth int) {
+	s.mu.Lock()
+	s.articleCache[mid] = articleCacheEntry{
+		articles:  articles,
+		depth:     depth,
+		updatedAt: time.Now(),
+	}
+	s.mu.Unlock()
+}
+
+// articleFetchTask 获取单个 UP 主专栏文章的任务
+type articleFetchTask struct {
+	service         *VideoService
+	channel         config.ChannelInfo
+	limit           int
+	cacheTTLSeconds int
+	resultChan      chan<- models.ArticleList
+	wg              *sync.WaitGroup
+}
+
+// Execute 实现 worker.Task 接口
+func (t *articleFetchTask) Execute() error {
+	defer t.wg.Done()
+
+	articles, err := t.service.fetchChannelArticles(t.channel.Mid, t.channel.Name, t.limit, t.cacheTTLSeconds)
+	if articles != nil {
+		t.resultChan <- articles
+	}
+	return err
+}
+
+// fetchChannelArticles 获取单个 UP 主的专栏文章，优先使用缓存，失败时降级为过期缓存
+// 降级时同时返回过期数据和错误
+func (s *VideoService) fetchChannelArticles(mid, name string, limit int, cacheTTLSeconds int) (models.ArticleList, error) {
+	// 1. 尝试从缓存获取
+	cachedArticles, cacheValid := s.getCachedArticles(mid, limit, cacheTTLSeconds)
+	if cacheValid {
+		logger.Debugw("命中有效专栏缓存",
+			"up_name", name,
+			"up_mid", mid,
+			"cached", true,
+		)
+		return cachedArticles, nil
+	}
+
+	// 为非缓存请求增加轻微抖动，避免多个频道同时触发风控。
+	time.Sleep(s.randomRequestDelay())
+
+	// 2. 从 API 获取
+	articles, err := s.client.FetchUserArticles(mid, limit, name)
+	if err != nil {
+		logger.Warnw("获取专栏失败",
+			"up_name", name,
+			"up_mid", mid,
+			"error", err,
+		)
+		s.recordFetchError("articles", mid, name, err, cachedArticles != nil)
+		// 容错降级：如果 API 失败且有旧缓存，返回旧缓存
+		return cachedArticles, err
+	}
+
+	// 3. 更新缓存
+	s.setCachedArticles(mid, articles, limit)
+
+	logger.Infow("获取专栏成功",
+		"up_name", name,
+		"up_mid", mid,
+		"article_count", len(articles),
+		"cached", false,
+	)
+	return articles, nil
+}
+
+// FetchAllArticles 并发获取所有专栏来源的文章并按时间排序
+func (s *VideoService) FetchAllArticles(limit int, cacheTTLSeconds int) (models.ArticleList, error) {
+	sources := s.GetConfig().Articles()
+	if len(sources) == 0 {
+		return models.ArticleList{}, nil
+	}
+
+	// 创建结果通道和同步等待组
+	articleChan := make(chan models.ArticleList, len(sources))
+	var executeWg sync.WaitGroup
+
+	// 提交任务到 Worker Pool
+	for _, channel := range sources {
+		executeWg.Add(1)
+
+		s.workerPool.Submit(&articleFetchTask{
+			service:         s,
+			channel:         channel,
+			limit:           limit,
+			cacheTTLSeconds: cacheTTLSeconds,
+			resultChan:      articleChan,
+			wg:              &executeWg,
+		})
+	}
+
+	// 等待所有任务执行完成后关闭通道
+	go func() {
+		executeWg.Wait()
+		close(articleChan)
+	}()
+
+	// 收集结果
+	var allArticles models.ArticleList
+	for articles := range articleChan {
+		allArticles = append(allArticles, articles...)
+	}
+
+	return allArticles.SortByNewest().Limit(limit), nil
+}
+
+// FetchChannelArticles 获取单个 UP 主的专栏文章
+func (s *VideoService) FetchChannelArticles(mid string, limit int, cacheTTLSeconds int) (models.ArticleList, error) {
+	articles, err := s.fetchChannelArticles(mid, "", limit, cacheTTLSeconds)
+	if articles == nil && err != nil {
+		return nil, err
+	}
+	return articles.Limit(limit), nil
+}
@@ -0,0 +1,198 @@
+// Package service 提供管理接口对频道与分组的修改
+package service
+
+import (
+	"errors"
+	"fmt"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// 频道管理错误，管理接口据此返回对应的状态码
+var (
+	ErrChannelNotFound = errors.New("频道不存在")
+	ErrChannelExists   = errors.New("频道已存在")
+	ErrInvalidChannel  = errors.New("频道配置无效")
+	ErrInvalidGroup    = errors.New("分组配置无效")
+)
+
+// ConfigFile 返回配置文件中的完整配置
+// 配置文件有误导致重载失败时，它与当前生效的配置可能不同
+func (s *VideoService) ConfigFile(configPath string) (*config.Config, error) {
+	return config.Load(configPath)
+}
+
+// ListChannels 返回配置文件中的频道列表，下标即增删改接口使用的 index
+// mid 保留配置文件中的写法，不解析为数字 mid
+func (s *VideoService) ListChannels(configPath string) ([]config.ChannelInfo, error) {
+	file, err := config.LoadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return file.Config.Channels, nil
+}
+
+// AddChannel 在频道列表末尾添加频道，返回补全名称后的频道与重载结果
+func (s *VideoService) AddChannel(configPath string, ch config.ChannelInfo) (config.ChannelInfo, models.ReloadResult, error) {
+	result, err := s.editConfig(configPath, ErrInvalidChannel, func(cfg, current *config.Config) error {
+		if err := s.verifyChannel(&ch); err != nil {
+			return err
+		}
+		for _, existing := range current.Channels {
+			if existing.CacheKey() == ch.CacheKey() {
+				return fmt.Errorf("%w: %s", ErrChannelExists, ch.CacheKey())
+			}
+		}
+		cfg.Channels = append(cfg.Channels, ch)
+		return nil
+	})
+	return ch, result, err
+}
+
+// UpdateChannel 替换第 index 个频道，mid 变化时重新校验
+func (s *VideoService) UpdateChannel(configPath string, index int, ch config.ChannelInfo) (config.ChannelInfo, models.ReloadResult, error) {
+	result, err := s.editConfig(configPath, ErrInvalidChannel, func(cfg, current *config.Config) error {
+		if index < 0 || index >= len(cfg.Channels) {
+			return fmt.Errorf("%w: index %d", ErrChannelNotFound, index)
+		}
+		// mid 未变化时保留原写法，按已解析的 mid 检查重复
+		resolved := ch
+		if ch.Mid == cfg.Channels[index].Mid {
+			resolved.Mid = current.Channels[index].Mid
+		} else {
+			if err := s.verifyChannel(&ch); err != nil {
+				return err
+			}
+			resolved = ch
+		}
+		for i, existing := range current.Channels {
+			if i != index && existing.CacheKey() == resolved.CacheKey() {
+				return fmt.Errorf("%w: %s", ErrChannelExists, resolved.CacheKey())
+			}
+		}
+		cfg.Channels[index] = ch
+		return nil
+	})
+	return ch, result, err
+}
+
+// DeleteChannel 删除第 index 个频道
+func (s *VideoService) DeleteChannel(configPath string, index int) (models.ReloadResult, error) {
+	return s.editConfig(configPath, ErrInvalidChannel, func(cfg, _ *config.Config) error {
+		if index < 0 || index >= len(cfg.Channels) {
+			return fmt.Errorf("%w: index %d", ErrChannelNotFound, index)
+		}
+		cfg.Channels = append(cfg.Channels[:index], cfg.Channels[index+1:]...)
+		return nil
+	})
+}
+
+// ReorderChannels 按 order 重新排列频道，order 为现有下标的一个排列
+func (s *VideoService) ReorderChannels(configPath string, order []int) (models.ReloadResult, error) {
+	return s.editConfig(configPath, ErrInvalidChannel, func(cfg, _ *config.Config) error {
+		if len(order) != len(cfg.Channels) {
+			return fmt.Errorf("%w: order 长度 %d 与频道数量 %d 不一致", ErrInvalidChannel, len(order), len(cfg.Channels))
+		}
+		seen := make([]bool, len(order))
+		reordered := make([]config.ChannelInfo, 0, len(order))
+		for _, i := range order {
+			if i < 0 || i >= len(cfg.Channels) || seen[i] {
+				return fmt.Errorf("%w: order 必须包含每个下标各一次", ErrInvalidChannel)
+			}
+			seen[i] = true
+			reordered = append(reordered, cfg.Channels[i])
+		}
+		cfg.Channels = reordered
+		return nil
+	})
+}
+
+// ListGroups 返回配置文件中的频道分组
+func (s *VideoService) ListGroups(configPath string) ([]config.GroupInfo, error) {
+	file, err := config.LoadFile(configPath)
+	if err != nil {
+		return nil, err
+	}
+	return file.Config.Groups, nil
+}
+
+// SetGroups 整体替换频道分组
+func (s *VideoService) SetGroups(configPath string, groups []config.GroupInfo) (models.ReloadResult, error) {
+	return s.editConfig(configPath, ErrInvalidGroup, func(cfg, _ *config.Config) error {
+		cfg.Groups = groups
+		return nil
+	})
+}
+
+// editConfig 以磁盘上的配置为基准修改并校验，写入后立即重新加载
+// edit 修改未解析 mid 的原始配置 cfg，写回时其余条目保持原写法；current 为解析后的配置，频道下标与 cfg 一致，用于检查重复
+// 校验失败时不写入文件，当前配置保持不变，返回的错误包装 invalid
+func (s *VideoService) editConfig(configPath string, invalid error, edit func(cfg, current *config.Config) error) (models.ReloadResult, error) {
+	s.editMu.Lock()
+	defer s.editMu.Unlock()
+
+	file, err := config.LoadFile(configPath)
+	if err != nil {
+		return models.ReloadResult{}, err
+	}
+	current, err := file.Resolve()
+	if err != nil {
+		return models.ReloadResult{}, err
+	}
+
+	if err := edit(file.Config, current); err != nil {
+		return models.ReloadResult{}, err
+	}
+	if _, err := file.Resolve(); err != nil {
+		return models.ReloadResult{}, fmt.Errorf("%w: %w", invalid, err)
+	}
+
+	// 写入与重载期间持有 reloadMu，配置文件监听不会将本次写入当作外部修改再次重载
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+
+	if err := file.Save(); err != nil {
+		return models.ReloadResult{}, err
+	}
+	logger.Infow("管理接口已修改配置",
+		"path", file.Path,
+		"channel_count", len(file.Config.Channels),
+		"group_count", len(file.Config.Groups),
+	)
+	return s.reloadLocked(file.Path, ReloadTriggerAdmin), nil
+}
+
+// verifyChannel 将 mid 引用解析为数字 mid，并通过 Bilibili 接口确认 UP 主存在
+// 名称为空时使用 UP 主昵称
+func (s *VideoService) verifyChannel(ch *config.ChannelInfo) error {
+	switch ch.SourceType() {
+	case config.SourceTypeUploads, config.SourceTypeSeason, config.SourceTypeSeries, config.SourceTypeArticle:
+	default:
+		return nil
+	}
+	if ch.Mid == "" {
+		// 缺少 mid 由配置校验给出具体错误
+		return nil
+	}
+
+	mid, name, err := config.ResolveMid(ch.Mid)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidChannel, err)
+	}
+	ch.Mid = mid
+
+	profile, err := s.client.FetchChannelProfile(mid, "")
+	if err != nil {
+		return fmt.Errorf("%w: 无法获取 mid %s 的 UP 主信息: %w", ErrInvalidChannel, mid, err)
+	}
+
+	if ch.Name == "" {
+		ch.Name = name
+	}
+	if ch.Name == "" {
+		ch.Name = profile.Name
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+// Package service 频道管理单元测试
+package service
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"glance-bilibili/internal/config"
+)
+
+// channelKeys 返回频道缓存键，便于比较顺序
+func channelKeys(channels []config.ChannelInfo) string {
+	keys := make([]string, 0, len(channels))
+	for _, ch := range channels {
+		keys = append(keys, ch.CacheKey())
+	}
+	return strings.Join(keys, ",")
+}
+
+// TestEditChannels 测试通过管理接口增删改与排序频道
+func TestEditChannels(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"channels": [{"type": "popular", "name": "热门"}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewVideoService(cfg, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	if _, result, err := s.AddChannel(path, config.ChannelInfo{Type: config.SourceTypeRanking, Rid: 188}); err != nil || !result.Success {
+		t.Fatalf("AddChannel() error = %v, result = %+v", err, result)
+	}
+	if _, _, err := s.AddChannel(path, config.ChannelInfo{Type: config.SourceTypeBangumi, SeasonID: "45969"}); err != nil {
+		t.Fatalf("AddChannel() error = %v", err)
+	}
+	if _, err := s.ReorderChannels(path, []int{2, 0, 1}); err != nil {
+		t.Fatalf("ReorderChannels() error = %v", err)
+	}
+	if _, _, err := s.UpdateChannel(path, 2, config.ChannelInfo{Type: config.SourceTypeRanking, Rid: 36}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	if _, err := s.DeleteChannel(path, 1); err != nil {
+		t.Fatalf("DeleteChannel() error = %v", err)
+	}
+
+	want := "bangumi:45969,ranking:36"
+	if got := channelKeys(s.GetConfig().Channels); got != want {
+		t.Errorf("运行中的频道 = %s, want %s", got, want)
+	}
+	saved, err := s.ListChannels(path)
+	if err != nil || channelKeys(saved) != want {
+		t.Errorf("配置文件中的频道 = %s, err = %v, want %s", channelKeys(saved), err, want)
+	}
+
+	errTests := []struct {
+		name string
+		edit func() error
+		want error
+	}{
+		{"重复添加", func() error {
+			_, _, err := s.AddChannel(path, config.ChannelInfo{Type: config.SourceTypeRanking, Rid: 36})
+			return err
+		}, ErrChannelExists},
+		{"配置无效", func() error {
+			_, _, err := s.AddChannel(path, config.ChannelInfo{Type: config.SourceTypeFavorite})
+			return err
+		}, ErrInvalidChannel},
+		{"下标越界", func() error {
+			_, err := s.DeleteChannel(path, 5)
+			return err
+		}, ErrChannelNotFound},
+		{"排序不是排列", func() error {
+			_, err := s.ReorderChannels(path, []int{0, 0})
+			return err
+		}, ErrInvalidChannel},
+	}
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.edit(); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	// 失败的修改不写入文件
+	if saved, _ := s.ListChannels(path); channelKeys(saved) != want {
+		t.Errorf("失败后配置文件中的频道 = %s, want %s", channelKeys(saved), want)
+	}
+	if matches, _ := filepath.Glob(filepath.Join(filepath.Dir(path), ".*.tmp")); len(matches) != 0 {
+		t.Errorf("残留临时文件: %v", matches)
+	}
+}
+
+// TestEditChannels_KeepsOriginal 测试管理接口修改 YAML 配置时保留注释与未解析的 mid 写法
+func TestEditChannels_KeepsOriginal(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := `# 首页订阅
+channels:
+  # 科技区
+  - mid: https://space.bilibili.com/946974
+    name: 影视飓风 # 自定义名称
+  - type: popular
+    name: 热门
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := NewVideoService(cfg, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	if _, _, err := s.AddChannel(path, config.ChannelInfo{Type: config.SourceTypeRanking, Rid: 188}); err != nil {
+		t.Fatalf("AddChannel() error = %v", err)
+	}
+	if _, _, err := s.UpdateChannel(path, 1, config.ChannelInfo{Type: config.SourceTypePopular, Name: "综合热门"}); err != nil {
+		t.Fatalf("UpdateChannel() error = %v", err)
+	}
+	if _, _, err := s.UpdateChannel(path, 0, config.ChannelInfo{Mid: "https://space.bilibili.com/946974", Name: "影视飓风", Limit: 5}); err != nil {
+		t.Fatalf("UpdateChannel() 保留 mid 写法时 error = %v", err)
+	}
+
+	data, _ := os.ReadFile(path)
+	for _, want := range []string{"# 首页订阅", "# 科技区", "mid: https://space.bilibili.com/946974", "name: 影视飓风 # 自定义名称", "limit: 5", "name: 综合热门", "rid: 188"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("配置文件缺少 %q:\n%s", want, data)
+		}
+	}
+
+	saved, err := s.ListChannels(path)
+	if err != nil {
+		t.Fatalf("ListChannels() error = %v", err)
+	}
+	if saved[0].Mid != "https://space.bilibili.com/946974" {
+		t.Errorf("ListChannels() mid = %s, want 原始写法", saved[0].Mid)
+	}
+	if got := s.GetConfig().Channels[0].Mid; got != "946974" {
+		t.Errorf("运行中的 mid = %s, want 946974", got)
+	}
+}
@@ -0,0 +1,180 @@
+// Package service 提供 UP 主频道资料获取
+package service
+
+import (
+	"sync"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// DefaultChannelCacheTTL 频道资料缓存的默认有效期（秒），资料变化很慢
+	DefaultChannelCacheTTL = 6 * 3600
+)
+
+func (s *VideoService) getCachedChannel(mid string, cacheTTLSeconds int) (models.Channel, bool, bool) {
+	s.mu.RLock()
+	channel, exists := s.channelCache[mid]
+	s.mu.RUnlock()
+
+	if !exists {
+		return models.Channel{}, false, false
+	}
+
+	// 缓存存在但已过期，仍返回旧数据供降级兜底使用
+	if time.Since(channel.UpdatedAt) >= time.Duration(cacheTTLSeconds)*time.Second {
+		return channel, true, false
+	}
+
+	return channel, true, true
+}
+
+func (s *VideoService) setCachedChannel(channel models.Channel) {
+	s.mu.Lock()
+	s.channelCache[channel.Mid] = channel
+	s.mu.Unlock()
+}
+
+// channelFetchTask 获取单个 UP 主频道资料的任务
+type channelFetchTask struct {
+	service         *VideoService
+	channel         config.ChannelInfo
+	cacheTTLSeconds int
+	resultChan      chan<- models.Channel
+	wg              *sync.WaitGroup
+}
+
+// Execute 实现 worker.Task 接口
+func (t *channelFetchTask) Execute() error {
+	defer t.wg.Done()
+
+	// 1. 尝试从缓存获取
+	cached, exists, cacheValid := t.service.getCachedChannel(t.channel.Mid, t.cacheTTLSeconds)
+	if cacheValid {
+		t.resultChan <- cached
+		return nil
+	}
+
+	// 为非缓存请求增加轻微抖动，避免多个频道同时触发风控。
+	time.Sleep(t.service.randomRequestDelay())
+
+	// 2. 从 API 获取
+	profile, err := t.service.fetchProfile(t.channel.Mid, t.channel.Name)
+	if err != nil {
+		logger.Warnw("获取频道资料失败",
+			"up_name", t.channel.Name,
+			"up_mid", t.channel.Mid,
+			"error", err,
+		)
+		t.service.recordFetchError("channels", t.channel.Mid, t.channel.Name, err, exists)
+		// 容错降级：如果 API 失败且有旧缓存，返回旧缓存
+		if exists {
+			t.resultChan <- cached
+		}
+		return err
+	}
+
+	// 3. 更新缓存
+	t.service.setCachedChannel(profile)
+	t.resultChan <- profile
+	return nil
+}
+
+// FetchChannels 并发获取所有已配置 UP 主的频道资料，按配置顺序返回
+// 获取失败且无缓存的 UP 主会被跳过
+func (s *VideoService) FetchChannels(cacheTTLSeconds int) (models.ChannelList, error) {
+	uploaders := s.GetConfig().Uploaders()
+	if len(uploaders) == 0 {
+		return models.ChannelList{}, nil
+	}
+
+	// 创建结果通道和同步等待组
+	channelChan := make(chan models.Channel, len(uploaders))
+	var executeWg sync.WaitGroup
+
+	// 提交任务到 Worker Pool
+	for _, channel := range uploaders {
+		executeWg.Add(1)
+
+		s.workerPool.Submit(&channelFetchTask{
+			service:         s,
+			channel:         channel,
+			cacheTTLSeconds: cacheTTLSeconds,
+			resultChan:      channelChan,
+			wg:              &executeWg,
+		})
+	}
+
+	// 等待所有任务执行完成后关闭通道
+	go func() {
+		executeWg.Wait()
+		close(channelChan)
+	}()
+
+	// 收集结果
+	profiles := make(map[string]models.Channel, len(uploaders))
+	for c := range channelChan {
+		profiles[c.Mid] = c
+	}
+
+	channels := make(models.ChannelList, 0, len(profiles))
+	for _, channel := range uploaders {
+		if c, ok := profiles[channel.Mid]; ok {
+			channels = append(channels, c)
+		}
+	}
+
+	return channels, nil
+}
+
+// StartChannelRefresh 启动后台定期刷新频道资料，首次刷新立即执行
+func (s *VideoService) StartChannelRefresh(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	go func() {
+		s.refreshChannels()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			s.refreshChannels()
+		}
+	}()
+}
+
+// refreshChannels 重新获取所有频道资料，忽略缓存的有效期；获取失败时保留旧缓存
+// 缓存时间在获取完成时记录，若以刷新间隔作为有效期，下一次刷新时缓存仍然有效，实际间隔会翻倍
+func (s *VideoService) refreshChannels() models.ChannelList {
+	channels, _ := s.FetchChannels(0)
+	logger.Infow("频道资料刷新完成",
+		"channel_count", len(channels),
+	)
+	return channels
+}
+
+// AttachAvatars 使用已缓存的频道资料为视频填充 UP 主头像
+// 返回新的列表，不修改传入的（可能来自缓存的）列表；不会触发任何请求
+func (s *VideoService) AttachAvatars(videos models.VideoList) models.VideoList {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if len(s.channelCache) == 0 {
+		return videos
+	}
+
+	result := make(models.VideoList, len(videos))
+	copy(result, videos)
+	for i := range result {
+		if result[i].AuthorFace != "" || result[i].AuthorMid == "" {
+			continue
+		}
+		if c, ok := s.channelCache[result[i].AuthorMid]; ok {
+			result[i].AuthorFace = c.Face
+		}
+	}
+	return result
+}
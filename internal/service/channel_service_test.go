@@ -0,0 +1,42 @@
+// Package service 频道资料单元测试
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// TestRefreshChannels 测试后台刷新忽略缓存有效期：第二次刷新也会重新获取，失败时保留旧缓存
+func TestRefreshChannels(t *testing.T) {
+	cfg := &config.Config{Channels: []config.ChannelInfo{{Mid: "1", Name: "测试"}}}
+	s := NewVideoService(cfg, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	calls := 0
+	var fetchErr error
+	s.fetchProfile = func(mid string, nameOverride string) (models.Channel, error) {
+		calls++
+		if fetchErr != nil {
+			return models.Channel{}, fetchErr
+		}
+		return models.Channel{Mid: mid, Name: nameOverride, Follower: calls, UpdatedAt: time.Now()}, nil
+	}
+
+	if channels := s.refreshChannels(); len(channels) != 1 || channels[0].Follower != 1 {
+		t.Fatalf("首次刷新 = %+v", channels)
+	}
+
+	// 第二次刷新时缓存仍在有效期内
+	if channels := s.refreshChannels(); calls != 2 || len(channels) != 1 || channels[0].Follower != 2 {
+		t.Fatalf("第二次刷新应重新获取, calls = %d, channels = %+v", calls, channels)
+	}
+
+	fetchErr = errors.New("请求失败")
+	if channels := s.refreshChannels(); calls != 3 || len(channels) != 1 || channels[0].Follower != 2 {
+		t.Errorf("获取失败时应返回旧缓存, calls = %d, channels = %+v", calls, channels)
+	}
+}
@@ -0,0 +1,158 @@
+// Package service 提供动态获取
+package service
+
+import (
+	"sync"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// feedCacheEntry 动态缓存条目
+type feedCacheEntry struct {
+	items     models.FeedItemList
+	depth     int // 获取时请求的数量，请求更多数据时缓存视为无效
+	updatedAt time.Time
+}
+
+func (s *VideoService) getCachedDynamics(mid string, limit int, cacheTTLSeconds int) (models.FeedItemList, bool) {
+	s.mu.RLock()
+	entry, exists := s.feedCache[mid]
+	s.mu.RUnlock()
+
+	if !exists {
+		return nil, false
+	}
+
+	// 缓存存在但已过期或数量不足，仍返回旧数据供降级兜底使用
+	if time.Since(entry.updatedAt) >= time.Duration(cacheTTLSeconds)*time.Second || entry.depth < limit {
+		return entry.items, false
+	}
+
+	return entry.items, true
+}
+
+func (s *VideoService) setCachedDynamics(mid string, items models.FeedItemList, depth int) {
+	s.mu.Lock()
+	s.feedCache[mid] = feedCacheEntry{
+		items:     items,
+		depth:     depth,
+		updatedAt: time.Now(),
+	}
+	s.mu.Unlock()
+}
+
+// dynamicFetchTask 获取单个频道动态的任务
+type dynamicFetchTask struct {
+	service         *VideoService
+	channel         config.ChannelInfo
+	limit           int
+	cacheTTLSeconds int
+	resultChan      chan<- models.FeedItemList
+	wg              *sync.WaitGroup
+}
+
+// Execute 实现 worker.Task 接口
+func (t *dynamicFetchTask) Execute() error {
+	defer t.wg.Done()
+
+	items, err := t.service.fetchChannelDynamics(t.channel.Mid, t.channel.Name, t.limit, t.cacheTTLSeconds)
+	if items != nil {
+		t.resultChan <- items
+	}
+	return err
+}
+
+// fetchChannelDynamics 获取单个频道的动态，优先使用缓存，失败时降级为过期缓存
+// 降级时同时返回过期数据和错误
+func (s *VideoService) fetchChannelDynamics(mid, name string, limit int, cacheTTLSeconds int) (models.FeedItemList, error) {
+	// 1. 尝试从缓存获取
+	cachedItems, cacheValid := s.getCachedDynamics(mid, limit, cacheTTLSeconds)
+	if cacheValid {
+		logger.Debugw("命中有效动态缓存",
+			"up_name", name,
+			"up_mid", mid,
+			"cached", true,
+		)
+		return cachedItems, nil
+	}
+
+	// 为非缓存请求增加轻微抖动，避免多个频道同时触发风控。
+	time.Sleep(s.randomRequestDelay())
+
+	// 2. 从 API 获取
+	items, err := s.client.FetchUserDynamics(mid, limit, name)
+	if err != nil {
+		logger.Warnw("获取动态失败",
+			"up_name", name,
+			"up_mid", mid,
+			"error", err,
+		)
+		s.recordFetchError("dynamics", mid, name, err, cachedItems != nil)
+		// 容错降级：如果 API 失败且有旧缓存，返回旧缓存
+		return cachedItems, err
+	}
+
+	// 3. 更新缓存
+	s.setCachedDynamics(mid, items, limit)
+
+	logger.Infow("获取动态成功",
+		"up_name", name,
+		"up_mid", mid,
+		"item_count", len(items),
+		"cached", false,
+	)
+	return items, nil
+}
+
+// FetchAllDynamics 并发获取所有 UP 主的动态并按时间排序
+// types 为空时返回全部类型
+func (s *VideoService) FetchAllDynamics(limit int, types []string, cacheTTLSeconds int) (models.FeedItemList, error) {
+	uploaders := s.GetConfig().Uploaders()
+	if len(uploaders) == 0 {
+		return models.FeedItemList{}, nil
+	}
+
+	// 创建结果通道和同步等待组
+	itemChan := make(chan models.FeedItemList, len(uploaders))
+	var executeWg sync.WaitGroup
+
+	// 提交任务到 Worker Pool
+	for _, channel := range uploaders {
+		executeWg.Add(1)
+
+		s.workerPool.Submit(&dynamicFetchTask{
+			service:         s,
+			channel:         channel,
+			limit:           limit,
+			cacheTTLSeconds: cacheTTLSeconds,
+			resultChan:      itemChan,
+			wg:              &executeWg,
+		})
+	}
+
+	// 等待所有任务执行完成后关闭通道
+	go func() {
+		executeWg.Wait()
+		close(itemChan)
+	}()
+
+	// 收集结果
+	var allItems models.FeedItemList
+	for items := range itemChan {
+		allItems = append(allItems, items...)
+	}
+
+	return allItems.FilterTypes(types).SortByNewest().Limit(limit), nil
+}
+
+// FetchChannelDynamics 获取单个 UP 主的动态
+func (s *VideoService) FetchChannelDynamics(mid string, limit int, types []string, cacheTTLSeconds int) (models.FeedItemList, error) {
+	items, err := s.fetchChannelDynamics(mid, "", limit, cacheTTLSeconds)
+	if items == nil && err != nil {
+		return nil, err
+	}
+	return items.FilterTypes(types).SortByNewest().Limit(limit), nil
+}
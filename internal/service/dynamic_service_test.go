@@ -0,0 +1,39 @@
+// Package service 动态缓存单元测试
+package service
+
+import (
+	"testing"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// TestGetCachedDynamics_Depth 测试缓存数量不足时视为未命中
+func TestGetCachedDynamics_Depth(t *testing.T) {
+	s := NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	s.setCachedDynamics("1", models.FeedItemList{{ID: "1"}, {ID: "2"}, {ID: "3"}}, 3)
+
+	tests := []struct {
+		name  string
+		limit int
+		valid bool
+	}{
+		{"请求数量相同", 3, true},
+		{"请求数量更少", 1, true},
+		{"请求数量更多", 30, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, valid := s.getCachedDynamics("1", tt.limit, 300)
+			if valid != tt.valid {
+				t.Errorf("valid = %v, want %v", valid, tt.valid)
+			}
+			if len(items) != 3 {
+				t.Errorf("应返回缓存数据供降级使用, got %d 条", len(items))
+			}
+		})
+	}
+}
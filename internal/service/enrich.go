@@ -0,0 +1,164 @@
+// Package service 提供视频数据补全
+package service
+
+import (
+	"sync"
+	"time"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// DefaultStatsCacheTTL 视频详情缓存的默认有效期（秒），互动数据变化较慢，缓存时间长于列表
+	DefaultStatsCacheTTL = 3600
+	// maxEnrichCount 单次请求最多补全的视频数量，避免放大上游请求
+	maxEnrichCount = 30
+)
+
+// detailCacheEntry 视频详情缓存条目
+type detailCacheEntry struct {
+	detail    models.VideoDetail
+	updatedAt time.Time
+}
+
+func (s *VideoService) getCachedDetail(bvid string, cacheTTLSeconds int) (models.VideoDetail, bool, bool) {
+	s.mu.RLock()
+	entry, exists := s.detailCache[bvid]
+	s.mu.RUnlock()
+
+	if !exists {
+		return models.VideoDetail{}, false, false
+	}
+
+	// 缓存存在但已过期，仍返回旧数据供降级兜底使用
+	if time.Since(entry.updatedAt) >= time.Duration(cacheTTLSeconds)*time.Second {
+		return entry.detail, true, false
+	}
+
+	return entry.detail, true, true
+}
+
+func (s *VideoService) setCachedDetail(detail models.VideoDetail) {
+	s.mu.Lock()
+	s.detailCache[detail.Bvid] = detailCacheEntry{
+		detail:    detail,
+		updatedAt: time.Now(),
+	}
+	s.mu.Unlock()
+}
+
+// detailFetchTask 获取单个视频详情的任务
+type detailFetchTask struct {
+	service         *VideoService
+	bvid            string
+	cacheTTLSeconds int
+	resultChan      chan<- models.VideoDetail
+	wg              *sync.WaitGroup
+}
+
+// Execute 实现 worker.Task 接口
+func (t *detailFetchTask) Execute() error {
+	defer t.wg.Done()
+
+	// 1. 尝试从缓存获取
+	cached, exists, cacheValid := t.service.getCachedDetail(t.bvid, t.cacheTTLSeconds)
+	if cacheValid {
+		t.resultChan <- cached
+		return nil
+	}
+
+	// 为非缓存请求增加轻微抖动，避免同时触发风控。
+	time.Sleep(t.service.randomRequestDelay())
+
+	// 2. 从 API 获取
+	detail, err := t.service.client.FetchVideoDetail(t.bvid)
+	if err != nil {
+		logger.Warnw("获取视频详情失败",
+			"bvid", t.bvid,
+			"error", err,
+		)
+		t.service.recordFetchError("details", t.bvid, "", err, exists)
+		// 容错降级：如果 API 失败且有旧缓存，返回旧缓存
+		if exists {
+			t.resultChan <- cached
+		}
+		return err
+	}
+
+	// 3. 更新缓存
+	t.service.setCachedDetail(detail)
+	t.resultChan <- detail
+	return nil
+}
+
+// EnrichVideos 为列表前 topN 个视频补全互动数据、简介与标签
+// 返回新的列表，不修改传入的（可能来自缓存的）列表；获取失败的视频保持原样
+func (s *VideoService) EnrichVideos(videos models.VideoList, topN int, cacheTTLSeconds int) models.VideoList {
+	if topN > maxEnrichCount {
+		topN = maxEnrichCount
+	}
+	if topN > len(videos) {
+		topN = len(videos)
+	}
+	if topN <= 0 {
+		return videos
+	}
+
+	// 收集需要补全的 BV 号（同一视频可能出现多次）
+	bvids := make([]string, 0, topN)
+	seen := make(map[string]bool, topN)
+	for _, v := range videos[:topN] {
+		if v.Bvid == "" || seen[v.Bvid] {
+			continue
+		}
+		seen[v.Bvid] = true
+		bvids = append(bvids, v.Bvid)
+	}
+	if len(bvids) == 0 {
+		return videos
+	}
+
+	// 创建结果通道和同步等待组
+	detailChan := make(chan models.VideoDetail, len(bvids))
+	var executeWg sync.WaitGroup
+
+	// 提交任务到 Worker Pool
+	for _, bvid := range bvids {
+		executeWg.Add(1)
+
+		s.workerPool.Submit(&detailFetchTask{
+			service:         s,
+			bvid:            bvid,
+			cacheTTLSeconds: cacheTTLSeconds,
+			resultChan:      detailChan,
+			wg:              &executeWg,
+		})
+	}
+
+	// 等待所有任务执行完成后关闭通道
+	go func() {
+		executeWg.Wait()
+		close(detailChan)
+	}()
+
+	// 收集结果
+	details := make(map[string]models.VideoDetail, len(bvids))
+	for d := range detailChan {
+		details[d.Bvid] = d
+	}
+
+	enriched := make(models.VideoList, len(videos))
+	copy(enriched, videos)
+	for i := range enriched[:topN] {
+		if d, ok := details[enriched[i].Bvid]; ok {
+			enriched[i].ApplyDetail(d)
+		}
+	}
+
+	logger.Debugw("视频数据补全完成",
+		"requested", len(bvids),
+		"enriched", len(details),
+	)
+	return enriched
+}
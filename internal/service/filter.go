@@ -0,0 +1,105 @@
+// Package service 提供来源级别的视频过滤
+package service
+
+import (
+	"regexp"
+	"slices"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// sourceFilter 编译后的来源过滤条件
+type sourceFilter struct {
+	include     []*regexp.Regexp
+	exclude     []*regexp.Regexp
+	minDuration int // 秒
+	maxDuration int // 秒
+	tids        []int
+	excludeTids []int
+	limit       int
+}
+
+// newSourceFilter 根据来源配置创建过滤器，未设置任何过滤条件与数量限制时返回 nil
+// 配置加载时已校验正则，这里编译失败的正则会被忽略
+func newSourceFilter(ch config.ChannelInfo) *sourceFilter {
+	if ch.Limit <= 0 && ch.FilterOptions.IsZero() {
+		return nil
+	}
+	return &sourceFilter{
+		include:     compilePatterns(ch.Include),
+		exclude:     compilePatterns(ch.Exclude),
+		minDuration: ch.MinDuration.Seconds(),
+		maxDuration: ch.MaxDuration.Seconds(),
+		tids:        ch.Tids,
+		excludeTids: ch.ExcludeTids,
+		limit:       ch.Limit,
+	}
+}
+
+// compilePatterns 编译标题正则
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if re, err := regexp.Compile(p); err == nil {
+			result = append(result, re)
+		}
+	}
+	return result
+}
+
+// apply 返回过滤后的新列表，保持来源原有顺序，最多保留 limit 条
+// 不修改传入的列表（可能来自缓存）
+func (f *sourceFilter) apply(videos models.VideoList) models.VideoList {
+	if f == nil {
+		return videos
+	}
+	result := make(models.VideoList, 0, len(videos))
+	for _, v := range videos {
+		if f.limit > 0 && len(result) >= f.limit {
+			break
+		}
+		if f.match(v) {
+			result = append(result, v)
+		}
+	}
+	return result
+}
+
+// match 判断视频是否满足过滤条件
+// 时长或分区未知的视频不受对应条件影响
+func (f *sourceFilter) match(v models.Video) bool {
+	if len(f.include) > 0 && !matchAny(f.include, v.Title) {
+		return false
+	}
+	if matchAny(f.exclude, v.Title) {
+		return false
+	}
+	if seconds := v.DurationSeconds(); seconds > 0 {
+		if f.minDuration > 0 && seconds < f.minDuration {
+			return false
+		}
+		if f.maxDuration > 0 && seconds > f.maxDuration {
+			return false
+		}
+	}
+	if v.Tid != 0 {
+		if len(f.tids) > 0 && !slices.Contains(f.tids, v.Tid) {
+			return false
+		}
+		if slices.Contains(f.excludeTids, v.Tid) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchAny 判断标题是否匹配任一正则
+func matchAny(patterns []*regexp.Regexp, title string) bool {
+	for _, re := range patterns {
+		if re.MatchString(title) {
+			return true
+		}
+	}
+	return false
+}
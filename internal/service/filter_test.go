@@ -0,0 +1,83 @@
+// Package service 来源过滤单元测试
+package service
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// TestSourceFilter_Apply 测试来源级别的过滤与数量限制
+func TestSourceFilter_Apply(t *testing.T) {
+	videos := models.VideoList{
+		{Bvid: "BV1", Title: "【直播回放】周五杂谈", Duration: "3:12:00", Tid: 21},
+		{Bvid: "BV2", Title: "新品评测", Duration: "12:30", Tid: 95},
+		{Bvid: "BV3", Title: "一分钟速览", Duration: "00:45", Tid: 95},
+		{Bvid: "BV4", Title: "开箱", Duration: "08:00", Tid: 21},
+		{Bvid: "BV5", Title: "番外", Duration: ""},
+	}
+
+	tests := []struct {
+		name     string
+		channel  config.ChannelInfo
+		expected string
+	}{
+		{
+			name:     "无过滤条件",
+			channel:  config.ChannelInfo{Mid: "1"},
+			expected: "BV1,BV2,BV3,BV4,BV5",
+		},
+		{
+			name:     "排除标题",
+			channel:  config.ChannelInfo{FilterOptions: config.FilterOptions{Exclude: []string{"直播回放"}}},
+			expected: "BV2,BV3,BV4,BV5",
+		},
+		{
+			name:     "包含标题",
+			channel:  config.ChannelInfo{FilterOptions: config.FilterOptions{Include: []string{"评测", "^开箱$"}}},
+			expected: "BV2,BV4",
+		},
+		{
+			name: "时长范围，时长未知的保留",
+			channel: config.ChannelInfo{FilterOptions: config.FilterOptions{
+				MinDuration: config.Duration(time.Minute), MaxDuration: config.Duration(time.Hour),
+			}},
+			expected: "BV2,BV4,BV5",
+		},
+		{
+			name:     "仅保留分区，分区未知的保留",
+			channel:  config.ChannelInfo{FilterOptions: config.FilterOptions{Tids: []int{95}}},
+			expected: "BV2,BV3,BV5",
+		},
+		{
+			name:     "排除分区",
+			channel:  config.ChannelInfo{FilterOptions: config.FilterOptions{ExcludeTids: []int{21}}},
+			expected: "BV2,BV3,BV5",
+		},
+		{
+			name:     "过滤后限制数量",
+			channel:  config.ChannelInfo{Limit: 2, FilterOptions: config.FilterOptions{Exclude: []string{"直播回放"}}},
+			expected: "BV2,BV3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := newSourceFilter(tt.channel).apply(videos)
+			bvids := make([]string, 0, len(got))
+			for _, v := range got {
+				bvids = append(bvids, v.Bvid)
+			}
+			if strings.Join(bvids, ",") != tt.expected {
+				t.Errorf("apply() = %v, want %s", bvids, tt.expected)
+			}
+		})
+	}
+
+	if len(videos) != 5 || videos[0].Bvid != "BV1" {
+		t.Error("apply() 不应修改传入的列表")
+	}
+}
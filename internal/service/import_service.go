@@ -0,0 +1,58 @@
+// Package service 提供关注列表导入
+package service
+
+import (
+	"fmt"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+)
+
+// ImportFollowings 将 mid 的公开关注列表合并进配置文件
+// 以磁盘上的配置为基准合并，dryRun 为 true 时仅返回变更而不写入文件
+// 写入后由配置文件监听或管理接口触发重新加载
+func (s *VideoService) ImportFollowings(mid string, configPath string, dryRun bool) (config.ImportDiff, error) {
+	followings, err := s.client.FetchFollowings(mid)
+	if err != nil {
+		return config.ImportDiff{}, fmt.Errorf("获取关注列表失败: %w", err)
+	}
+
+	imported := make([]config.ChannelInfo, 0, len(followings))
+	for _, f := range followings {
+		imported = append(imported, config.ChannelInfo{Mid: f.Mid, Name: f.Name})
+	}
+
+	// 按解析后的 mid 合并，写回时只修改原始配置，保留已有条目的链接与 @名称写法
+	file, err := config.LoadFile(configPath)
+	if err != nil {
+		return config.ImportDiff{}, err
+	}
+	cfg, err := file.Resolve()
+	if err != nil {
+		return config.ImportDiff{}, err
+	}
+
+	merged, diff := cfg.MergeChannels(imported)
+	logger.Infow("关注列表合并完成",
+		"mid", mid,
+		"following_count", len(followings),
+		"added", len(diff.Added),
+		"named", len(diff.Named),
+		"dry_run", dryRun,
+	)
+
+	if dryRun || !diff.HasChanges() {
+		return diff, nil
+	}
+
+	// 合并只会补全已有条目的名称并在末尾追加新条目
+	for i := range cfg.Channels {
+		file.Config.Channels[i].Name = merged.Channels[i].Name
+	}
+	file.Config.Channels = append(file.Config.Channels, merged.Channels[len(cfg.Channels):]...)
+
+	if err := file.Save(); err != nil {
+		return config.ImportDiff{}, err
+	}
+	return diff, nil
+}
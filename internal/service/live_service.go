@@ -0,0 +1,121 @@
+// Package service 提供直播间状态查询
+package service
+
+import (
+	"strings"
+	"time"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// liveCacheEntry 直播间状态缓存条目
+type liveCacheEntry struct {
+	room      models.LiveRoom
+	updatedAt time.Time
+}
+
+func (s *VideoService) getCachedLiveRoom(mid string, cacheTTLSeconds int) (models.LiveRoom, bool, bool) {
+	s.mu.RLock()
+	entry, exists := s.liveCache[mid]
+	s.mu.RUnlock()
+
+	if !exists {
+		return models.LiveRoom{}, false, false
+	}
+
+	// 缓存存在但已过期，仍返回旧数据供降级兜底使用
+	valid := time.Since(entry.updatedAt) < time.Duration(cacheTTLSeconds)*time.Second
+	return entry.room, true, valid
+}
+
+func (s *VideoService) setCachedLiveRooms(rooms map[string]models.LiveRoom) {
+	now := time.Now()
+	s.mu.Lock()
+	for mid, room := range rooms {
+		s.liveCache[mid] = liveCacheEntry{
+			room:      room,
+			updatedAt: now,
+		}
+	}
+	s.mu.Unlock()
+}
+
+// FetchLiveRooms 获取所有已配置 UP 主的直播间状态
+// 直播中的排在前面；onlyLive 为 true 时过滤掉未开播的直播间
+func (s *VideoService) FetchLiveRooms(onlyLive bool, cacheTTLSeconds int) (models.LiveRoomList, error) {
+	uploaders := s.GetConfig().Uploaders()
+	mids := make([]string, 0, len(uploaders))
+	names := make(map[string]string, len(uploaders))
+	for _, channel := range uploaders {
+		if _, seen := names[channel.Mid]; seen {
+			continue
+		}
+		mids = append(mids, channel.Mid)
+		names[channel.Mid] = channel.Name
+	}
+
+	return s.fetchLiveRooms(mids, names, onlyLive, cacheTTLSeconds)
+}
+
+// FetchChannelLiveRoom 获取单个 UP 主的直播间状态
+func (s *VideoService) FetchChannelLiveRoom(mid string, onlyLive bool, cacheTTLSeconds int) (models.LiveRoomList, error) {
+	return s.fetchLiveRooms([]string{mid}, nil, onlyLive, cacheTTLSeconds)
+}
+
+func (s *VideoService) fetchLiveRooms(mids []string, names map[string]string, onlyLive bool, cacheTTLSeconds int) (models.LiveRoomList, error) {
+	if len(mids) == 0 {
+		return models.LiveRoomList{}, nil
+	}
+
+	// 1. 区分缓存命中与需要刷新的 mid，未命中的合并为一次批量请求
+	rooms := make(models.LiveRoomList, 0, len(mids))
+	stale := make(map[string]models.LiveRoom)
+	var missing []string
+	for _, mid := range mids {
+		room, exists, valid := s.getCachedLiveRoom(mid, cacheTTLSeconds)
+		if valid {
+			rooms = append(rooms, room)
+			continue
+		}
+		if exists {
+			stale[mid] = room
+		}
+		missing = append(missing, mid)
+	}
+
+	// 2. 从 API 批量获取
+	if len(missing) > 0 {
+		fetched, err := s.client.FetchLiveRooms(missing, names)
+		if err != nil {
+			logger.Warnw("获取直播间状态失败",
+				"up_count", len(missing),
+				"error", err,
+			)
+			s.recordFetchError("live", strings.Join(missing, ","), "", err, len(stale) > 0)
+			// 容错降级：API 失败时返回过期缓存，全无数据时才报错
+			if len(stale) == 0 && len(rooms) == 0 {
+				return nil, err
+			}
+			for _, room := range stale {
+				rooms = append(rooms, room)
+			}
+		} else {
+			// 3. 更新缓存
+			s.setCachedLiveRooms(fetched)
+			for _, mid := range missing {
+				rooms = append(rooms, fetched[mid])
+			}
+			logger.Infow("获取直播间状态成功",
+				"up_count", len(missing),
+				"cached", false,
+			)
+		}
+	}
+
+	rooms = rooms.SortByStatus()
+	if onlyLive {
+		rooms = rooms.OnlyLive()
+	}
+	return rooms, nil
+}
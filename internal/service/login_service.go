@@ -0,0 +1,85 @@
+// Package service 提供扫码登录
+package service
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+	"glance-bilibili/internal/platform"
+)
+
+// UseCredentialFile 指定扫码登录凭据文件，需在 Initialize 之前调用
+// 文件存在时其中的凭据优先于配置文件中的 credential，环境变量设置的凭据优先级最高
+func (s *VideoService) UseCredentialFile(path string) error {
+	s.credentialPath = path
+
+	cr, err := platform.LoadCredentialFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if cr.IsZero() {
+		return nil
+	}
+
+	if config.HasEnvCredential() {
+		logger.Infow("已通过环境变量设置登录凭据，忽略凭据文件", "path", path)
+		return nil
+	}
+
+	platform.SetCredential(cr)
+	logger.Infow("已加载扫码登录凭据", "path", path)
+	return nil
+}
+
+// CredentialPath 返回扫码登录凭据文件路径
+func (s *VideoService) CredentialPath() string {
+	return s.credentialPath
+}
+
+// StartQRLogin 申请扫码登录二维码
+func (s *VideoService) StartQRLogin() (models.QRLogin, error) {
+	return s.client.GenerateQRLogin()
+}
+
+// PollQRLogin 查询扫码登录状态
+// 确认登录后将凭据写入凭据文件并立即启用，无需重启服务
+func (s *VideoService) PollQRLogin(key string) (models.QRLoginState, error) {
+	state, cr, err := s.client.PollQRLogin(key)
+	if err != nil || state != models.QRLoginConfirmed {
+		return state, err
+	}
+
+	if s.credentialPath == "" {
+		return "", fmt.Errorf("未指定凭据文件路径")
+	}
+	if err := platform.SaveCredentialFile(s.credentialPath, cr); err != nil {
+		return "", err
+	}
+	logger.Infow("扫码登录成功，凭据已写入",
+		"path", s.credentialPath,
+		"mid", cr.DedeUserID,
+	)
+
+	platform.SetCredential(cr)
+	// 重新获取 WBI 密钥，同时校验登录状态
+	if err := s.client.Initialize(); err != nil {
+		logger.Warnw("登录后初始化失败", "error", err)
+	}
+	return state, nil
+}
+
+// StartCookieRefresh 启动后台定期检查并刷新登录 Cookie，刷新后的凭据写入凭据文件
+func (s *VideoService) StartCookieRefresh(interval time.Duration) {
+	if s.credentialPath == "" {
+		return
+	}
+	s.client.StartCookieRefresh(s.credentialPath, interval)
+}
@@ -0,0 +1,209 @@
+// Package service 提供分页浏览
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+const (
+	// catalogPageSize 分页浏览时每次向接口请求的数量
+	catalogPageSize = 50
+	// maxCatalogPages 分页浏览时最多向后读取的接口页数（约 1000 条投稿）
+	maxCatalogPages = 20
+	// maxAggregatePage 汇总模式下允许的最大页码，避免一次请求抓取过多数据
+	maxAggregatePage = 10
+)
+
+// 分页参数错误，由请求参数不合法导致，接口据此返回 400
+var (
+	ErrInvalidCursor  = errors.New("无效的游标")
+	ErrPageOutOfRange = errors.New("页码超出范围")
+)
+
+// catalogPage 读取 UP 主投稿列表的第 pn 页（每页 catalogPageSize 条），每页独立缓存
+// 返回本页视频以及是否还有下一页
+func (s *VideoService) catalogPage(mid string, pn int, cacheTTLSeconds int) (models.VideoList, bool, error) {
+	cacheKey := fmt.Sprintf("%s#page%d", mid, pn)
+
+	// 1. 尝试从缓存获取
+	cachedVideos, cacheValid := s.getCachedVideos(cacheKey, catalogPageSize, cacheTTLSeconds)
+	if cacheValid {
+		return cachedVideos, len(cachedVideos) >= catalogPageSize, nil
+	}
+
+	// 翻页之间增加抖动，避免连续请求触发风控。
+	time.Sleep(s.randomRequestDelay())
+
+	// 2. 从 API 获取
+	page, err := s.client.FetchUserVideosPage(mid, pn, catalogPageSize, "")
+	if err != nil {
+		logger.Warnw("分页获取视频失败",
+			"up_mid", mid,
+			"page", pn,
+			"error", err,
+		)
+		s.recordFetchError("videos", cacheKey, "", err, cachedVideos != nil)
+		if cachedVideos != nil {
+			return cachedVideos, len(cachedVideos) >= catalogPageSize, nil
+		}
+		return nil, false, err
+	}
+
+	// 3. 更新缓存
+	s.setCachedVideos(cacheKey, page.Videos, catalogPageSize)
+
+	return page.Videos, pn*catalogPageSize < page.Total, nil
+}
+
+// FetchChannelVideosPage 分页获取单个 UP 主的投稿
+// cursor 非空时返回游标之后（更早）的 limit 条视频，否则返回第 page 页（从 1 开始，每页 limit 条）
+func (s *VideoService) FetchChannelVideosPage(mid string, page int, cursor string, limit int, cacheTTLSeconds int) (models.VideoPage, error) {
+	if cursor == "" && page <= 1 {
+		// 首页与普通请求共用缓存
+		videos, err := s.FetchChannelVideos(mid, limit, cacheTTLSeconds)
+		if err != nil {
+			return models.VideoPage{}, err
+		}
+		return buildVideoPage(videos, 1, limit, len(videos) >= limit), nil
+	}
+
+	var accept func(models.Video) bool
+	startPn := 1
+	skip := 0
+	if cursor != "" {
+		before, bvid, err := parseCursor(cursor)
+		if err != nil {
+			return models.VideoPage{}, err
+		}
+		accept = afterCursor(before, bvid)
+		page = 0
+	} else {
+		offset := (page - 1) * limit
+		startPn = offset/catalogPageSize + 1
+		skip = offset % catalogPageSize
+	}
+
+	videos := make(models.VideoList, 0, limit)
+	hasMore := false
+	for pn := startPn; pn <= maxCatalogPages; pn++ {
+		pageVideos, more, err := s.catalogPage(mid, pn, cacheTTLSeconds)
+		if err != nil {
+			if len(videos) > 0 {
+				break
+			}
+			return models.VideoPage{}, err
+		}
+
+		for i, v := range pageVideos {
+			if pn == startPn && i < skip {
+				continue
+			}
+			if accept != nil && !accept(v) {
+				continue
+			}
+			if len(videos) >= limit {
+				hasMore = true
+				break
+			}
+			videos = append(videos, v)
+		}
+
+		if hasMore || !more {
+			break
+		}
+		if len(videos) >= limit {
+			// 恰好取满且接口仍有下一页
+			hasMore = true
+			break
+		}
+	}
+
+	return buildVideoPage(videos, page, limit, hasMore), nil
+}
+
+// FetchAllVideosPage 分页获取所有 UP 主（或指定分组）的视频汇总（第 page 页，每页 limit 条）
+// 汇总模式需要为每个来源抓取 page*limit 条数据，因此页码有上限，且不支持游标
+func (s *VideoService) FetchAllVideosPage(group string, page int, limit int, cacheTTLSeconds int) (models.VideoPage, error) {
+	if page > maxAggregatePage {
+		return models.VideoPage{}, fmt.Errorf("%w: 汇总模式最多支持 %d 页", ErrPageOutOfRange, maxAggregatePage)
+	}
+	if page < 1 {
+		page = 1
+	}
+
+	videos, err := s.FetchAllVideos(group, page*limit, cacheTTLSeconds)
+	if err != nil {
+		return models.VideoPage{}, err
+	}
+
+	// 汇总结果恰好填满时认为可能还有下一页
+	hasMore := len(videos) >= page*limit && page < maxAggregatePage
+
+	start := (page - 1) * limit
+	if start >= len(videos) {
+		return models.VideoPage{Videos: models.VideoList{}, Page: page}, nil
+	}
+	result := buildVideoPage(videos[start:], page, limit, hasMore)
+	// 汇总模式不支持游标
+	result.NextCursor = ""
+	return result, nil
+}
+
+// buildVideoPage 组装分页结果，hasMore 为 true 时生成下一页页码与游标
+func buildVideoPage(videos models.VideoList, page int, limit int, hasMore bool) models.VideoPage {
+	result := models.VideoPage{
+		Videos: videos.Limit(limit),
+		Page:   page,
+	}
+	if !hasMore || len(result.Videos) == 0 {
+		return result
+	}
+
+	if page > 0 {
+		result.NextPage = page + 1
+	}
+	last := result.Videos[len(result.Videos)-1]
+	result.NextCursor = formatCursor(last)
+	return result
+}
+
+// formatCursor 生成游标: <发布时间戳>_<BV 号>
+func formatCursor(v models.Video) string {
+	return strconv.FormatInt(v.TimePosted.Unix(), 10) + "_" + v.Bvid
+}
+
+// parseCursor 解析游标，返回发布时间与 BV 号
+func parseCursor(cursor string) (time.Time, string, error) {
+	tsStr, bvid, _ := strings.Cut(cursor, "_")
+	ts, err := strconv.ParseInt(tsStr, 10, 64)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("%w: %s", ErrInvalidCursor, cursor)
+	}
+	return time.Unix(ts, 0), bvid, nil
+}
+
+// afterCursor 返回判断视频是否位于游标之后的函数
+// 投稿列表按发布时间倒序，发布时间相同时以游标中的 BV 号定位，跳过它及之前的视频
+func afterCursor(before time.Time, bvid string) func(models.Video) bool {
+	passed := false
+	return func(v models.Video) bool {
+		if passed {
+			return true
+		}
+		if v.TimePosted.Before(before) {
+			passed = true
+			return true
+		}
+		if bvid != "" && v.Bvid == bvid {
+			passed = true
+		}
+		return false
+	}
+}
@@ -0,0 +1,128 @@
+// Package service 分页浏览单元测试
+package service
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// TestParseCursor 测试游标解析
+func TestParseCursor(t *testing.T) {
+	tests := []struct {
+		name     string
+		cursor   string
+		wantTime int64
+		wantBvid string
+		wantErr  bool
+	}{
+		{name: "时间戳与 BV 号", cursor: "1700000000_BV1xx411c7mD", wantTime: 1700000000, wantBvid: "BV1xx411c7mD"},
+		{name: "仅时间戳", cursor: "1700000000", wantTime: 1700000000, wantBvid: ""},
+		{name: "无效时间戳", cursor: "abc_BV1", wantErr: true},
+		{name: "空游标", cursor: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, bvid, err := parseCursor(tt.cursor)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidCursor)) {
+				t.Fatalf("parseCursor(%q) error = %v, wantErr %v", tt.cursor, err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if ts.Unix() != tt.wantTime || bvid != tt.wantBvid {
+				t.Errorf("parseCursor(%q) = (%d, %s), want (%d, %s)", tt.cursor, ts.Unix(), bvid, tt.wantTime, tt.wantBvid)
+			}
+		})
+	}
+}
+
+// TestAfterCursor 测试游标定位
+func TestAfterCursor(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	// 按发布时间倒序，B 与 C 发布时间相同
+	videos := models.VideoList{
+		{Bvid: "A", TimePosted: base.Add(time.Hour)},
+		{Bvid: "B", TimePosted: base},
+		{Bvid: "C", TimePosted: base},
+		{Bvid: "D", TimePosted: base.Add(-time.Hour)},
+	}
+
+	tests := []struct {
+		name     string
+		cursor   string
+		expected []string
+	}{
+		{name: "同一时间戳按 BV 号定位", cursor: formatCursor(videos[1]), expected: []string{"C", "D"}},
+		{name: "游标为最后一条", cursor: formatCursor(videos[3]), expected: []string{}},
+		{name: "BV 号不存在时按时间定位", cursor: "1700000000_BVmissing", expected: []string{"D"}},
+		{name: "游标早于所有视频", cursor: "1600000000_X", expected: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before, bvid, err := parseCursor(tt.cursor)
+			if err != nil {
+				t.Fatalf("parseCursor() error = %v", err)
+			}
+			accept := afterCursor(before, bvid)
+
+			got := []string{}
+			for _, v := range videos {
+				if accept(v) {
+					got = append(got, v.Bvid)
+				}
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("afterCursor() = %v, want %v", got, tt.expected)
+			}
+			for i := range got {
+				if got[i] != tt.expected[i] {
+					t.Errorf("afterCursor() = %v, want %v", got, tt.expected)
+					break
+				}
+			}
+		})
+	}
+}
+
+// TestBuildVideoPage 测试分页结果组装
+func TestBuildVideoPage(t *testing.T) {
+	videos := models.VideoList{
+		{Bvid: "A", TimePosted: time.Unix(300, 0)},
+		{Bvid: "B", TimePosted: time.Unix(200, 0)},
+		{Bvid: "C", TimePosted: time.Unix(100, 0)},
+	}
+
+	tests := []struct {
+		name       string
+		page       int
+		limit      int
+		hasMore    bool
+		wantLen    int
+		wantNext   int
+		wantCursor string
+	}{
+		{name: "还有下一页", page: 2, limit: 2, hasMore: true, wantLen: 2, wantNext: 3, wantCursor: "200_B"},
+		{name: "最后一页", page: 1, limit: 5, hasMore: false, wantLen: 3, wantNext: 0, wantCursor: ""},
+		{name: "游标模式不生成页码", page: 0, limit: 3, hasMore: true, wantLen: 3, wantNext: 0, wantCursor: "100_C"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := buildVideoPage(videos, tt.page, tt.limit, tt.hasMore)
+			if len(result.Videos) != tt.wantLen {
+				t.Errorf("len(Videos) = %d, want %d", len(result.Videos), tt.wantLen)
+			}
+			if result.NextPage != tt.wantNext {
+				t.Errorf("NextPage = %d, want %d", result.NextPage, tt.wantNext)
+			}
+			if result.NextCursor != tt.wantCursor {
+				t.Errorf("NextCursor = %s, want %s", result.NextCursor, tt.wantCursor)
+			}
+		})
+	}
+}
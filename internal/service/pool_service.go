@@ -0,0 +1,30 @@
+// Package service 提供请求身份池配置与状态查询
+package service
+
+import (
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+	"glance-bilibili/internal/platform"
+)
+
+// configureIdentities 按配置设置身份池中的额外身份与分配策略
+func configureIdentities(pool config.PoolConfig) {
+	extra := make([]platform.Identity, 0, len(pool.Identities))
+	for i, info := range pool.Identities {
+		extra = append(extra, platform.Identity{
+			Name: pool.IdentityName(i),
+			Credential: platform.Credential{
+				SESSDATA:   info.SESSDATA,
+				BiliJct:    info.BiliJct,
+				DedeUserID: info.DedeUserID,
+			},
+			UserAgent: info.UserAgent,
+		})
+	}
+	platform.ConfigureIdentities(extra, pool.Strategy)
+}
+
+// PoolStatus 返回身份池中各身份的使用与风控状态
+func (s *VideoService) PoolStatus() models.PoolStatus {
+	return platform.GetPoolStatus()
+}
@@ -0,0 +1,273 @@
+// Package service 提供配置热重载
+package service
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// 配置重载的触发方式
+const (
+	ReloadTriggerWatch = "watch" // 配置文件变化
+	ReloadTriggerAdmin = "admin" // 管理接口
+)
+
+// configStamp 配置文件的修改时间与大小，用于轮询检测变化
+type configStamp struct {
+	modTime int64
+	size    int64
+}
+
+func statConfig(path string) configStamp {
+	info, err := os.Stat(path)
+	if err != nil {
+		return configStamp{}
+	}
+	return configStamp{modTime: info.ModTime().UnixNano(), size: info.Size()}
+}
+
+// recordStat 记录配置文件当前的修改时间与大小，返回是否与上次记录的不同
+// 调用方需持有 reloadMu；重载与管理接口写入后都会记录，监听只对其他来源的修改触发重载
+func (s *VideoService) recordStat(path string) bool {
+	current := statConfig(path)
+	changed := current != s.configStamp
+	s.configStamp = current
+	return changed
+}
+
+// StartConfigWatch 后台轮询配置文件，修改时间或大小变化时重新加载
+// interval 小于等于 0 时不启动
+func (s *VideoService) StartConfigWatch(path string, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	s.reloadMu.Lock()
+	s.recordStat(path)
+	s.reloadMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for range ticker.C {
+			s.reloadMu.Lock()
+			if s.recordStat(path) {
+				s.reloadLocked(path, ReloadTriggerWatch)
+			}
+			s.reloadMu.Unlock()
+		}
+	}()
+
+	logger.Infow("已启动配置文件监听",
+		"path", path,
+		"interval", interval.String(),
+	)
+}
+
+// ReloadConfig 重新加载配置文件并整体替换当前配置
+// 加载或校验失败时保留当前配置；已移除来源的缓存会被清除
+// server、fetch、cache、render 与 credential 的修改需重启后生效，pool 的修改立即生效
+func (s *VideoService) ReloadConfig(path, trigger string) models.ReloadResult {
+	s.reloadMu.Lock()
+	defer s.reloadMu.Unlock()
+	return s.reloadLocked(path, trigger)
+}
+
+// reloadLocked 重新加载配置文件，调用方需持有 reloadMu
+func (s *VideoService) reloadLocked(path, trigger string) models.ReloadResult {
+	s.recordStat(path)
+
+	result := models.ReloadResult{Time: time.Now(), Trigger: trigger}
+	cfg, err := loadConfigFile(path)
+	if err != nil {
+		result.Error = err.Error()
+		logger.Warnw("配置重载失败，继续使用当前配置",
+			"path", path,
+			"trigger", trigger,
+			"error", err,
+		)
+		s.setLastReload(result)
+		return result
+	}
+
+	old := s.config.Swap(cfg)
+	added, removed := diffSources(old, cfg)
+	for _, ch := range added {
+		result.Added = append(result.Added, sourceLabel(ch))
+	}
+	for _, ch := range removed {
+		result.Removed = append(result.Removed, sourceLabel(ch))
+	}
+	result.Evicted = s.evictSources(removed, cfg)
+
+	if !reflect.DeepEqual(old.Pool, cfg.Pool) {
+		configureIdentities(cfg.Pool)
+	}
+	result.Restart = restartSections(old, cfg)
+	result.Success = true
+	result.Channels = len(cfg.Channels)
+
+	logger.Infow("配置重载完成",
+		"path", path,
+		"trigger", trigger,
+		"channels", result.Channels,
+		"added", len(result.Added),
+		"removed", len(result.Removed),
+		"evicted", result.Evicted,
+	)
+	if len(result.Restart) > 0 {
+		logger.Warnw("以下配置已修改，需重启服务后生效",
+			"sections", result.Restart,
+		)
+	}
+
+	s.setLastReload(result)
+	return result
+}
+
+// LastReload 返回最近一次配置重载结果，尚未重载时返回 false
+func (s *VideoService) LastReload() (models.ReloadResult, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.lastReload == nil {
+		return models.ReloadResult{}, false
+	}
+	return *s.lastReload, true
+}
+
+func (s *VideoService) setLastReload(result models.ReloadResult) {
+	s.mu.Lock()
+	s.lastReload = &result
+	s.mu.Unlock()
+}
+
+// loadConfigFile 加载配置文件
+// 与启动时不同，文件不存在视为错误，避免误删文件后清空全部来源
+func loadConfigFile(path string) (*config.Config, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, fmt.Errorf("读取配置文件失败: %w", err)
+	}
+	return config.Load(path)
+}
+
+// allSources 返回配置中启用的全部来源，含未参与汇总的关键词订阅
+// 停用的频道不计入，停用视为移除，其缓存随之清除
+func allSources(cfg *config.Config) []config.ChannelInfo {
+	sources := make([]config.ChannelInfo, 0, len(cfg.Channels)+len(cfg.Searches))
+	for _, ch := range cfg.Channels {
+		if ch.IsEnabled() {
+			sources = append(sources, ch)
+		}
+	}
+	for _, si := range cfg.Searches {
+		sources = append(sources, si.Source())
+	}
+	return sources
+}
+
+// diffSources 按缓存键比较新旧配置，返回新增与移除的来源（按配置顺序）
+func diffSources(old, cur *config.Config) (added, removed []config.ChannelInfo) {
+	return subtractSources(allSources(cur), allSources(old)), subtractSources(allSources(old), allSources(cur))
+}
+
+// subtractSources 返回 a 中缓存键不在 b 中的来源，结果按缓存键去重
+func subtractSources(a, b []config.ChannelInfo) []config.ChannelInfo {
+	seen := make(map[string]bool, len(b))
+	for _, ch := range b {
+		seen[ch.CacheKey()] = true
+	}
+
+	var result []config.ChannelInfo
+	for _, ch := range a {
+		if key := ch.CacheKey(); !seen[key] {
+			seen[key] = true
+			result = append(result, ch)
+		}
+	}
+	return result
+}
+
+// sourceLabel 返回来源的展示名称，未配置名称时使用缓存键
+func sourceLabel(ch config.ChannelInfo) string {
+	if ch.Name != "" {
+		return ch.Name + " (" + ch.CacheKey() + ")"
+	}
+	return ch.CacheKey()
+}
+
+// evictSources 清除已移除来源的缓存，返回清除的条目数
+// 按 mid 缓存的直播、动态、专栏与频道资料，仅在新配置中没有启用的来源引用该 mid 时清除
+func (s *VideoService) evictSources(removed []config.ChannelInfo, cur *config.Config) int {
+	if len(removed) == 0 {
+		return 0
+	}
+
+	mids := make(map[string]bool, len(cur.Channels))
+	for _, ch := range cur.Channels {
+		if ch.Mid != "" && ch.IsEnabled() {
+			mids[ch.Mid] = true
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	evicted := 0
+	for _, ch := range removed {
+		key := ch.CacheKey()
+		for k := range s.cache {
+			// UP 主投稿的分页缓存键为 <mid>#page<n>
+			if k == key || strings.HasPrefix(k, key+"#page") {
+				delete(s.cache, k)
+				evicted++
+			}
+		}
+
+		if ch.Mid == "" || mids[ch.Mid] {
+			continue
+		}
+		evicted += evict(s.liveCache, ch.Mid)
+		evicted += evict(s.feedCache, ch.Mid)
+		evicted += evict(s.articleCache, ch.Mid)
+		evicted += evict(s.channelCache, ch.Mid)
+	}
+	return evicted
+}
+
+// evict 删除缓存条目，返回删除的数量
+func evict[V any](cache map[string]V, key string) int {
+	if _, ok := cache[key]; !ok {
+		return 0
+	}
+	delete(cache, key)
+	return 1
+}
+
+// restartSections 返回已修改但需重启才生效的配置段
+func restartSections(old, cur *config.Config) []string {
+	var sections []string
+	if old.Server != cur.Server {
+		sections = append(sections, "server")
+	}
+	if old.Fetch != cur.Fetch {
+		sections = append(sections, "fetch")
+	}
+	if old.Cache != cur.Cache {
+		sections = append(sections, "cache")
+	}
+	if old.Render != cur.Render {
+		sections = append(sections, "render")
+	}
+	if old.Credential != cur.Credential {
+		sections = append(sections, "credential")
+	}
+	return sections
+}
@@ -0,0 +1,150 @@
+// Package service 配置热重载单元测试
+package service
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// TestDiffSources 测试新旧配置的来源比较
+func TestDiffSources(t *testing.T) {
+	old := &config.Config{
+		Channels: []config.ChannelInfo{{Mid: "1"}, {Mid: "2"}, {Type: config.SourceTypePopular}},
+		Searches: []config.SearchInfo{{Name: "go", Keyword: "golang"}},
+	}
+	cur := &config.Config{
+		Channels: []config.ChannelInfo{{Mid: "2", Name: "改名不算变更"}, {Mid: "3"}, {Mid: "3"}},
+		Searches: []config.SearchInfo{{Name: "go", Keyword: "golang", Merge: true}},
+	}
+
+	added, removed := diffSources(old, cur)
+	if len(added) != 1 || added[0].Mid != "3" {
+		t.Errorf("added = %+v, want [mid 3]", added)
+	}
+	if len(removed) != 2 || removed[0].Mid != "1" || removed[1].SourceType() != config.SourceTypePopular {
+		t.Errorf("removed = %+v, want [mid 1, popular]", removed)
+	}
+}
+
+// TestReloadConfig 测试重载后替换配置并清除已移除来源的缓存
+func TestReloadConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg := &config.Config{Channels: []config.ChannelInfo{
+		{Mid: "1"},
+		{Mid: "2"},
+		{Type: config.SourceTypeArticle, Mid: "3"},
+	}}
+	s := NewVideoService(cfg, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	s.setCachedVideos("1", models.VideoList{{Bvid: "BV1"}}, 1)
+	s.setCachedVideos("1#page2", models.VideoList{{Bvid: "BV2"}}, 1)
+	s.setCachedVideos("2", models.VideoList{{Bvid: "BV3"}}, 1)
+	s.setCachedChannel(models.Channel{Mid: "1"})
+	s.setCachedChannel(models.Channel{Mid: "3"})
+
+	// 移除 mid 1 的投稿与 mid 3 的专栏，mid 3 仍被投稿来源引用
+	write(`{"channels": [{"mid": "2"}, {"mid": "3"}, {"mid": "4", "name": "新 UP"}]}`)
+	result := s.ReloadConfig(path, ReloadTriggerAdmin)
+	if !result.Success {
+		t.Fatalf("ReloadConfig() error = %s", result.Error)
+	}
+	if len(s.GetConfig().Channels) != 3 || result.Channels != 3 {
+		t.Errorf("重载后 channels = %+v", s.GetConfig().Channels)
+	}
+	if len(result.Added) != 2 || len(result.Removed) != 2 || result.Evicted != 3 {
+		t.Errorf("result = %+v", result)
+	}
+	if _, ok := s.getCachedVideos("1", 1, 300); ok {
+		t.Error("已移除来源的缓存应被清除")
+	}
+	if _, ok := s.getCachedVideos("2", 1, 300); !ok {
+		t.Error("保留来源的缓存不应被清除")
+	}
+	if _, exists, _ := s.getCachedChannel("3", 300); !exists {
+		t.Error("仍被引用的 mid 的频道资料不应被清除")
+	}
+
+	// 停用来源视为移除，清除其缓存；重新启用视为新增
+	write(`{"channels": [{"mid": "2", "enabled": false}, {"mid": "3"}, {"mid": "4", "name": "新 UP"}]}`)
+	result = s.ReloadConfig(path, ReloadTriggerAdmin)
+	if len(result.Removed) != 1 || result.Removed[0] != "2" || result.Evicted != 1 {
+		t.Errorf("停用后 result = %+v", result)
+	}
+	if _, ok := s.getCachedVideos("2", 1, 300); ok {
+		t.Error("已停用来源的缓存应被清除")
+	}
+	write(`{"channels": [{"mid": "2"}, {"mid": "3"}, {"mid": "4", "name": "新 UP"}]}`)
+	if result := s.ReloadConfig(path, ReloadTriggerAdmin); len(result.Added) != 1 || result.Added[0] != "2" {
+		t.Errorf("重新启用后 result = %+v", result)
+	}
+
+	// 校验失败时保留当前配置
+	write(`{"channels": [{"type": "favorite"}]}`)
+	result = s.ReloadConfig(path, ReloadTriggerWatch)
+	if result.Success || result.Error == "" {
+		t.Errorf("非法配置应重载失败: %+v", result)
+	}
+	if len(s.GetConfig().Channels) != 3 {
+		t.Error("重载失败时不应替换配置")
+	}
+	if last, ok := s.LastReload(); !ok || last.Trigger != ReloadTriggerWatch || last.Success {
+		t.Errorf("LastReload() = %+v", last)
+	}
+
+	// 配置文件被删除时不应清空来源
+	os.Remove(path)
+	if result := s.ReloadConfig(path, ReloadTriggerWatch); result.Success {
+		t.Error("配置文件不存在时应重载失败")
+	}
+}
+
+// TestStartConfigWatch 测试配置文件变化后自动重载
+func TestStartConfigWatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"channels": []}`), 0644)
+
+	s := NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+	s.StartConfigWatch(path, 10*time.Millisecond)
+
+	os.WriteFile(path, []byte(`{"channels": [{"mid": "1"}]}`), 0644)
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if len(s.GetConfig().Channels) == 1 {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("配置文件变化后未自动重载")
+}
+
+// TestStartConfigWatch_AdminEdit 测试管理接口写入后监听不会再次重载并覆盖重载结果
+func TestStartConfigWatch_AdminEdit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	os.WriteFile(path, []byte(`{"channels": [{"type": "popular"}]}`), 0644)
+
+	s := NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+	s.StartConfigWatch(path, 10*time.Millisecond)
+
+	if _, _, err := s.AddChannel(path, config.ChannelInfo{Type: config.SourceTypeRanking, Rid: 36}); err != nil {
+		t.Fatalf("AddChannel() error = %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if last, ok := s.LastReload(); !ok || last.Trigger != ReloadTriggerAdmin {
+		t.Errorf("LastReload().Trigger = %s, want %s", last.Trigger, ReloadTriggerAdmin)
+	}
+}
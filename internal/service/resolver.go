@@ -0,0 +1,36 @@
+// Package service 提供配置中 UP 主引用的联网解析
+package service
+
+import (
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/platform"
+)
+
+// midResolver 基于 Bilibili 接口实现 config.MidResolver
+type midResolver struct {
+	client *platform.BilibiliClient
+}
+
+// NewMidResolver 创建用于加载配置的 mid 解析器
+func NewMidResolver() config.MidResolver {
+	return &midResolver{client: platform.NewBilibiliClient()}
+}
+
+// ExpandShortLink 展开 b23.tv 短链接
+func (r *midResolver) ExpandShortLink(link string) (string, error) {
+	return r.client.ExpandShortLink(link)
+}
+
+// SearchUsers 按名称搜索用户
+func (r *midResolver) SearchUsers(name string) ([]config.UserMatch, error) {
+	users, err := r.client.SearchUsers(name)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := make([]config.UserMatch, 0, len(users))
+	for _, u := range users {
+		matches = append(matches, config.UserMatch{Mid: u.Mid, Name: u.Name})
+	}
+	return matches, nil
+}
@@ -0,0 +1,74 @@
+// Package service 提供缓存状态与最近抓取错误，供管理后台查看
+package service
+
+import (
+	"sort"
+	"time"
+
+	"glance-bilibili/internal/models"
+)
+
+// MaxFetchErrors 保留的抓取失败记录条数
+const MaxFetchErrors = 100
+
+// recordFetchError 记录一次抓取失败，超出上限时丢弃最旧的记录
+func (s *VideoService) recordFetchError(kind, source, name string, err error, stale bool) {
+	record := models.FetchError{
+		Time:   time.Now(),
+		Kind:   kind,
+		Source: source,
+		Name:   name,
+		Error:  err.Error(),
+		Stale:  stale,
+	}
+
+	s.mu.Lock()
+	s.fetchErrors = append(s.fetchErrors, record)
+	if over := len(s.fetchErrors) - MaxFetchErrors; over > 0 {
+		s.fetchErrors = append(s.fetchErrors[:0], s.fetchErrors[over:]...)
+	}
+	s.mu.Unlock()
+}
+
+// RecentFetchErrors 返回最近的抓取失败记录，最新的在前
+func (s *VideoService) RecentFetchErrors() []models.FetchError {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := make([]models.FetchError, len(s.fetchErrors))
+	for i, e := range s.fetchErrors {
+		result[len(result)-1-i] = e
+	}
+	return result
+}
+
+// CacheStatus 返回各类缓存的条目、数据数量与更新时间，条目按更新时间倒序排列
+func (s *VideoService) CacheStatus() []models.CacheSection {
+	s.mu.RLock()
+	sections := []models.CacheSection{
+		{Kind: "videos", Entries: cacheEntries(s.cache, func(e cacheEntry) (int, time.Time) { return len(e.videos), e.updatedAt })},
+		{Kind: "live", Entries: cacheEntries(s.liveCache, func(e liveCacheEntry) (int, time.Time) { return 1, e.updatedAt })},
+		{Kind: "dynamics", Entries: cacheEntries(s.feedCache, func(e feedCacheEntry) (int, time.Time) { return len(e.items), e.updatedAt })},
+		{Kind: "articles", Entries: cacheEntries(s.articleCache, func(e articleCacheEntry) (int, time.Time) { return len(e.articles), e.updatedAt })},
+		{Kind: "channels", Entries: cacheEntries(s.channelCache, func(c models.Channel) (int, time.Time) { return 1, c.UpdatedAt })},
+		{Kind: "details", Entries: cacheEntries(s.detailCache, func(e detailCacheEntry) (int, time.Time) { return 1, e.updatedAt })},
+	}
+	s.mu.RUnlock()
+	return sections
+}
+
+// cacheEntries 将缓存 map 转换为条目状态列表，调用方需持有读锁
+func cacheEntries[V any](m map[string]V, stat func(V) (int, time.Time)) []models.CacheEntryStatus {
+	entries := make([]models.CacheEntryStatus, 0, len(m))
+	for key, v := range m {
+		items, updatedAt := stat(v)
+		entries = append(entries, models.CacheEntryStatus{Key: key, Items: items, UpdatedAt: updatedAt})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].UpdatedAt.Equal(entries[j].UpdatedAt) {
+			return entries[i].UpdatedAt.After(entries[j].UpdatedAt)
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
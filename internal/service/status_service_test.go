@@ -0,0 +1,70 @@
+// Package service 缓存状态与抓取错误单元测试
+package service
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// TestRecentFetchErrors 测试抓取错误按最新在前返回，且超出上限时丢弃最旧的记录
+func TestRecentFetchErrors(t *testing.T) {
+	s := NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	if errs := s.RecentFetchErrors(); len(errs) != 0 {
+		t.Fatalf("初始应无抓取错误, got %d", len(errs))
+	}
+
+	total := MaxFetchErrors + 5
+	for i := 0; i < total; i++ {
+		s.recordFetchError("videos", strconv.Itoa(i), "", errors.New("请求失败"), false)
+	}
+
+	errs := s.RecentFetchErrors()
+	if len(errs) != MaxFetchErrors {
+		t.Fatalf("记录数 = %d, want %d", len(errs), MaxFetchErrors)
+	}
+	if errs[0].Source != strconv.Itoa(total-1) {
+		t.Errorf("第一条 = %s, want 最新的 %d", errs[0].Source, total-1)
+	}
+	if last := errs[len(errs)-1].Source; last != "5" {
+		t.Errorf("最后一条 = %s, want 5", last)
+	}
+}
+
+// TestCacheStatus 测试缓存状态按更新时间倒序列出条目
+func TestCacheStatus(t *testing.T) {
+	s := NewVideoService(&config.Config{}, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	now := time.Now()
+	s.cache["1"] = cacheEntry{videos: models.VideoList{{Bvid: "BV1"}, {Bvid: "BV2"}}, updatedAt: now.Add(-time.Minute)}
+	s.cache["2"] = cacheEntry{videos: models.VideoList{{Bvid: "BV3"}}, updatedAt: now}
+
+	var videos *models.CacheSection
+	sections := s.CacheStatus()
+	for i := range sections {
+		if sections[i].Kind == "videos" {
+			videos = &sections[i]
+		} else if len(sections[i].Entries) != 0 {
+			t.Errorf("%s 缓存应为空, got %d 条", sections[i].Kind, len(sections[i].Entries))
+		}
+	}
+	if videos == nil {
+		t.Fatal("缺少 videos 缓存状态")
+	}
+	if len(videos.Entries) != 2 {
+		t.Fatalf("videos 条目数 = %d, want 2", len(videos.Entries))
+	}
+	if first := videos.Entries[0]; first.Key != "2" || first.Items != 1 {
+		t.Errorf("第一条 = %+v, want 最近更新的 2", first)
+	}
+	if second := videos.Entries[1]; second.Key != "1" || second.Items != 2 {
+		t.Errorf("第二条 = %+v, want 1", second)
+	}
+}
@@ -2,8 +2,11 @@
 package service
 
 import (
+	"errors"
+	"fmt"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"glance-bilibili/internal/config"
@@ -13,41 +16,75 @@ import (
 	"glance-bilibili/internal/worker"
 )
 
-const (
-	defaultWorkerCount    = 4
-	requestJitterMinDelay = 250 * time.Millisecond
-	requestJitterMaxDelay = 1200 * time.Millisecond
-)
-
 // cacheEntry 缓存条目
 type cacheEntry struct {
 	videos    models.VideoList
+	depth     int // 获取时请求的数量，请求更多数据时缓存视为无效
 	updatedAt time.Time
 }
 
 // VideoService 视频服务
 type VideoService struct {
-	client     *platform.BilibiliClient
-	config     *config.Config
-	cache      map[string]cacheEntry
-	mu         sync.RWMutex
-	workerPool *worker.Pool
+	client       *platform.BilibiliClient
+	config       atomic.Pointer[config.Config] // 热重载时整体替换
+	cache        map[string]cacheEntry
+	liveCache    map[string]liveCacheEntry
+	feedCache    map[string]feedCacheEntry
+	detailCache  map[string]detailCacheEntry
+	channelCache map[string]models.Channel
+	articleCache map[string]articleCacheEntry
+	mu           sync.RWMutex
+	workerPool   *worker.Pool
+
+	credentialPath string // 扫码登录凭据文件路径
+
+	reloadMu    sync.Mutex           // 串行化配置重载
+	configStamp configStamp          // 最近一次重载或写入时配置文件的修改时间与大小，受 reloadMu 保护
+	editMu      sync.Mutex           // 串行化管理接口对配置文件的修改
+	lastReload  *models.ReloadResult // 最近一次配置重载结果，受 mu 保护
+
+	fetchErrors []models.FetchError // 最近的抓取失败记录（旧的在前），受 mu 保护
+
+	// fetchProfile 获取 UP 主频道资料，测试时可替换
+	fetchProfile func(mid string, nameOverride string) (models.Channel, error)
+
+	// 非缓存请求前的随机延迟区间
+	jitterMin time.Duration
+	jitterMax time.Duration
 }
 
-// NewVideoService 创建视频服务
-func NewVideoService(cfg *config.Config) *VideoService {
+// NewVideoService 创建视频服务，fetch 为生效的抓取设置
+func NewVideoService(cfg *config.Config, fetch config.FetchConfig) *VideoService {
 	client := platform.NewBilibiliClient()
 
+	// 配置了登录凭据时，所有请求以登录身份发出
+	cred := cfg.LoginCredential()
+	platform.SetCredential(platform.Credential{
+		SESSDATA:   cred.SESSDATA,
+		BiliJct:    cred.BiliJct,
+		DedeUserID: cred.DedeUserID,
+	})
+	configureIdentities(cfg.Pool)
+
 	// 创建 Worker Pool，降低并发以减少被风控拦截的概率。
-	pool := worker.NewPool(defaultWorkerCount)
+	pool := worker.NewPool(fetch.Workers)
 	pool.Start()
 
-	return &VideoService{
-		client:     client,
-		config:     cfg,
-		cache:      make(map[string]cacheEntry),
-		workerPool: pool,
+	s := &VideoService{
+		client:       client,
+		cache:        make(map[string]cacheEntry),
+		liveCache:    make(map[string]liveCacheEntry),
+		feedCache:    make(map[string]feedCacheEntry),
+		detailCache:  make(map[string]detailCacheEntry),
+		channelCache: make(map[string]models.Channel),
+		articleCache: make(map[string]articleCacheEntry),
+		workerPool:   pool,
+		jitterMin:    fetch.JitterMin.Std(),
+		jitterMax:    fetch.JitterMax.Std(),
+		fetchProfile: client.FetchChannelProfile,
 	}
+	s.config.Store(cfg)
+	return s
 }
 
 // Initialize 初始化服务
@@ -55,7 +92,7 @@ func (s *VideoService) Initialize() error {
 	return s.client.Initialize()
 }
 
-func (s *VideoService) getCachedVideos(mid string, cacheTTLSeconds int) (models.VideoList, bool) {
+func (s *VideoService) getCachedVideos(mid string, limit int, cacheTTLSeconds int) (models.VideoList, bool) {
 	// 读取缓存条目
 	s.mu.RLock()
 	entry, exists := s.cache[mid]
@@ -65,19 +102,20 @@ func (s *VideoService) getCachedVideos(mid string, cacheTTLSeconds int) (models.
 		return nil, false
 	}
 
-	// 缓存存在但已过期，仍返回旧数据供降级兜底使用
-	if time.Since(entry.updatedAt) >= time.Duration(cacheTTLSeconds)*time.Second {
+	// 缓存存在但已过期或数量不足，仍返回旧数据供降级兜底使用
+	if time.Since(entry.updatedAt) >= time.Duration(cacheTTLSeconds)*time.Second || entry.depth < limit {
 		return entry.videos, false
 	}
 
 	return entry.videos, true
 }
 
-func (s *VideoService) setCachedVideos(mid string, videos models.VideoList) {
+func (s *VideoService) setCachedVideos(mid string, videos models.VideoList, depth int) {
 	// 更新缓存
 	s.mu.Lock()
 	s.cache[mid] = cacheEntry{
 		videos:    videos,
+		depth:     depth,
 		updatedAt: time.Now(),
 	}
 	s.mu.Unlock()
@@ -97,66 +135,134 @@ type fetchTask struct {
 func (t *fetchTask) Execute() error {
 	defer t.wg.Done()
 
+	cacheKey := t.channel.CacheKey()
+	// 来源级别的过滤与数量限制只作用于汇总结果，缓存中保存未过滤的列表
+	filter := newSourceFilter(t.channel)
+
 	// 1. 尝试从缓存获取
-	cachedVideos, cacheValid := t.service.getCachedVideos(t.channel.Mid, t.cacheTTLSeconds)
+	cachedVideos, cacheValid := t.service.getCachedVideos(cacheKey, t.limit, t.cacheTTLSeconds)
 	if cacheValid {
 		logger.Debugw("命中有效缓存",
 			"up_name", t.channel.Name,
-			"up_mid", t.channel.Mid,
+			"source", cacheKey,
 			"cached", true,
 		)
-		t.resultChan <- cachedVideos
+		t.resultChan <- filter.apply(cachedVideos)
 		return nil
 	}
 
 	// 为非缓存请求增加轻微抖动，避免多个频道同时触发风控。
-	time.Sleep(randomRequestDelay())
+	time.Sleep(t.service.randomRequestDelay())
 
 	// 2. 缓存不存在或已过期，从 API 获取
-	videos, err := t.service.client.FetchUserVideos(t.channel.Mid, t.limit, t.channel.Name)
+	videos, err := t.service.fetchSourceVideos(t.channel, t.limit)
 	if err != nil {
 		logger.Warnw("获取视频失败",
 			"up_name", t.channel.Name,
-			"up_mid", t.channel.Mid,
+			"source", cacheKey,
 			"error", err,
 		)
+		t.service.recordFetchError("videos", cacheKey, t.channel.Name, err, cachedVideos != nil)
 		// 容错降级：如果 API 失败且有旧缓存，返回旧缓存
 		if cachedVideos != nil {
 			logger.Infow("API 失败，返回过期缓存数据",
 				"up_name", t.channel.Name,
 				"cached", true,
 			)
-			t.resultChan <- cachedVideos
+			t.resultChan <- filter.apply(cachedVideos)
 		}
 		return err
 	}
 
 	// 3. 更新缓存
-	t.service.setCachedVideos(t.channel.Mid, videos)
+	t.service.setCachedVideos(cacheKey, videos, t.limit)
 
 	logger.Infow("获取视频成功",
 		"up_name", t.channel.Name,
-		"up_mid", t.channel.Mid,
+		"source", cacheKey,
 		"video_count", len(videos),
 		"cached", false,
 	)
-	t.resultChan <- videos
+	t.resultChan <- filter.apply(videos)
 	return nil
 }
 
+// fetchSourceVideos 根据来源类型从 API 获取视频
+func (s *VideoService) fetchSourceVideos(channel config.ChannelInfo, limit int) (models.VideoList, error) {
+	switch channel.SourceType() {
+	case config.SourceTypeFavorite:
+		return s.client.FetchFavoriteVideos(channel.MediaID, limit, channel.FavoriteOrder())
+	case config.SourceTypeSeason:
+		return s.client.FetchSeasonVideos(channel.Mid, channel.SeasonID, limit, channel.Name)
+	case config.SourceTypeSeries:
+		return s.client.FetchSeriesVideos(channel.Mid, channel.SeriesID, limit, channel.Name)
+	case config.SourceTypePopular:
+		return s.client.FetchPopularVideos(limit)
+	case config.SourceTypeWeekly:
+		return s.client.FetchWeeklyVideos(channel.Number, limit)
+	case config.SourceTypeRanking:
+		return s.client.FetchRankingVideos(channel.Rid, limit)
+	case config.SourceTypeSearch:
+		return s.client.SearchVideos(channel.Keyword, limit)
+	case config.SourceTypeBangumi:
+		return s.client.FetchBangumiEpisodes(channel.SeasonID, limit, channel.Name)
+	case config.SourceTypeTimeline:
+		return s.client.FetchFollowingTimeline(limit)
+	case config.SourceTypeWatchLater:
+		return s.client.FetchWatchLater(limit)
+	case config.SourceTypeHistory:
+		return s.client.FetchHistory(limit)
+	case config.SourceTypeArticle:
+		// 专栏文章转换为视频条目后参与汇总，结果同时写入专栏缓存供 /articles 使用
+		articles, err := s.fetchChannelArticles(channel.Mid, channel.Name, limit, 0)
+		if err != nil {
+			return nil, err
+		}
+		return articles.Videos(), nil
+	default:
+		return s.client.FetchUserVideos(channel.Mid, limit, channel.Name)
+	}
+}
+
+// ErrGroupNotFound 请求的频道分组未配置
+var ErrGroupNotFound = errors.New("未找到频道分组")
+
+// sources 返回参与汇总的来源，group 不为空时仅返回该分组内的来源
+// 未指定分组、未配置任何来源但配置了登录凭据时，使用登录用户的关注时间线
+func (s *VideoService) sources(group string) ([]config.ChannelInfo, error) {
+	cfg := s.GetConfig()
+	if group != "" {
+		g, ok := cfg.FindGroup(group)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrGroupNotFound, group)
+		}
+		return cfg.GroupSources(g), nil
+	}
+
+	sources := cfg.Sources()
+	if len(sources) == 0 && platform.GetLoginStatus().Configured {
+		return []config.ChannelInfo{config.TimelineSource()}, nil
+	}
+	return sources, nil
+}
+
 // FetchAllVideos 并发获取所有 UP 主的视频并按时间排序
-// cacheTTLSeconds 缓存有效期（秒）
-func (s *VideoService) FetchAllVideos(limit int, cacheTTLSeconds int) (models.VideoList, error) {
-	if len(s.config.Channels) == 0 {
+// group 不为空时仅汇总该分组内的来源；cacheTTLSeconds 缓存有效期（秒）
+func (s *VideoService) FetchAllVideos(group string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	sources, err := s.sources(group)
+	if err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
 		return models.VideoList{}, nil
 	}
 
 	// 创建结果通道和同步等待组
-	videoChan := make(chan models.VideoList, len(s.config.Channels))
+	videoChan := make(chan models.VideoList, len(sources))
 	var executeWg sync.WaitGroup
 
 	// 提交任务到 Worker Pool
-	for _, channel := range s.config.Channels {
+	for _, channel := range sources {
 		executeWg.Add(1)
 
 		s.workerPool.Submit(&fetchTask{
@@ -186,39 +292,125 @@ func (s *VideoService) FetchAllVideos(limit int, cacheTTLSeconds int) (models.Vi
 		return nil, nil
 	}
 
-	// 按时间排序并限制数量
-	return allVideos.SortByNewest().Limit(limit), nil
+	// 按时间排序、去重（同一视频可能同时来自 UP 主与关键词订阅）并限制数量
+	return allVideos.SortByNewest().DedupeByBvid().Limit(limit), nil
 }
 
 // FetchChannelVideos 获取单个 UP 主的视频
 func (s *VideoService) FetchChannelVideos(mid string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	return s.fetchSingleSource(config.ChannelInfo{Mid: mid}, limit, cacheTTLSeconds)
+}
+
+// FetchFavoriteVideos 获取单个收藏夹的视频
+func (s *VideoService) FetchFavoriteVideos(mediaID string, order string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	return s.fetchSingleSource(config.ChannelInfo{
+		Type:    config.SourceTypeFavorite,
+		MediaID: mediaID,
+		Order:   order,
+	}, limit, cacheTTLSeconds)
+}
+
+// FetchSeasonVideos 获取 UP 主单个合集的视频
+func (s *VideoService) FetchSeasonVideos(mid, seasonID string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	return s.fetchSingleSource(config.ChannelInfo{
+		Type:     config.SourceTypeSeason,
+		Mid:      mid,
+		SeasonID: seasonID,
+	}, limit, cacheTTLSeconds)
+}
+
+// FetchSeriesVideos 获取 UP 主单个系列的视频
+func (s *VideoService) FetchSeriesVideos(mid, seriesID string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	return s.fetchSingleSource(config.ChannelInfo{
+		Type:     config.SourceTypeSeries,
+		Mid:      mid,
+		SeriesID: seriesID,
+	}, limit, cacheTTLSeconds)
+}
+
+// FetchBangumiEpisodes 获取单部番剧的最新剧集
+func (s *VideoService) FetchBangumiEpisodes(seasonID string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	return s.fetchSingleSource(config.ChannelInfo{
+		Type:     config.SourceTypeBangumi,
+		SeasonID: seasonID,
+	}, limit, cacheTTLSeconds)
+}
+
+// FetchSearchVideos 获取指定名称的关键词订阅结果
+func (s *VideoService) FetchSearchVideos(name string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	search, ok := s.GetConfig().FindSearch(name)
+	if !ok {
+		return nil, fmt.Errorf("未找到关键词订阅: %s", name)
+	}
+	return s.fetchSingleSource(search.Source(), limit, cacheTTLSeconds)
+}
+
+// FetchHotVideos 获取热门类来源（popular/weekly/ranking）的视频，保留接口返回的排名顺序
+func (s *VideoService) FetchHotVideos(sourceType string, rid int, number int, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	channel := config.ChannelInfo{
+		Type:   sourceType,
+		Rid:    rid,
+		Number: number,
+	}
+	if !channel.IsRanked() {
+		return nil, fmt.Errorf("不支持的热门来源: %s", sourceType)
+	}
+	return s.fetchSingleSource(channel, limit, cacheTTLSeconds)
+}
+
+// FetchPersonalVideos 获取登录用户的个人来源（timeline/watchlater/history）
+func (s *VideoService) FetchPersonalVideos(sourceType string, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	channel := config.ChannelInfo{Type: sourceType}
+	if !channel.IsPersonal() {
+		return nil, fmt.Errorf("不支持的个人来源: %s", sourceType)
+	}
+	return s.fetchSingleSource(channel, limit, cacheTTLSeconds)
+}
+
+// fetchSingleSource 获取单个来源的视频，优先使用缓存，失败时降级为过期缓存
+// 自带排名顺序的来源保留原顺序，其余按发布时间倒序
+func (s *VideoService) fetchSingleSource(channel config.ChannelInfo, limit int, cacheTTLSeconds int) (models.VideoList, error) {
+	cacheKey := channel.CacheKey()
+	arrange := func(videos models.VideoList) models.VideoList {
+		if channel.IsRanked() {
+			return videos.Limit(limit)
+		}
+		return videos.SortByNewest().Limit(limit)
+	}
+
 	// 1. 尝试从缓存获取
-	cachedVideos, cacheValid := s.getCachedVideos(mid, cacheTTLSeconds)
+	cachedVideos, cacheValid := s.getCachedVideos(cacheKey, limit, cacheTTLSeconds)
 	if cacheValid {
-		return cachedVideos.SortByNewest().Limit(limit), nil
+		return arrange(cachedVideos), nil
 	}
 
 	// 为非缓存请求增加轻微抖动，避免与批量抓取同时触发风控。
-	time.Sleep(randomRequestDelay())
+	time.Sleep(s.randomRequestDelay())
 
 	// 2. 从 API 获取
-	videos, err := s.client.FetchUserVideos(mid, limit, "")
+	videos, err := s.fetchSourceVideos(channel, limit)
 	if err != nil {
+		s.recordFetchError("videos", cacheKey, channel.Name, err, cachedVideos != nil)
 		if cachedVideos != nil {
-			return cachedVideos.SortByNewest().Limit(limit), nil
+			return arrange(cachedVideos), nil
 		}
 		return nil, err
 	}
 
 	// 3. 更新缓存
-	s.setCachedVideos(mid, videos)
+	s.setCachedVideos(cacheKey, videos, limit)
+
+	return arrange(videos), nil
+}
 
-	return videos.SortByNewest().Limit(limit), nil
+// LoginStatus 返回登录状态
+func (s *VideoService) LoginStatus() models.LoginStatus {
+	return platform.GetLoginStatus()
 }
 
-// GetConfig 获取配置
+// GetConfig 获取当前生效的配置，热重载后返回新配置
 func (s *VideoService) GetConfig() *config.Config {
-	return s.config
+	return s.config.Load()
 }
 
 // Shutdown 关闭服务（优雅关闭 Worker Pool）
@@ -228,10 +420,11 @@ func (s *VideoService) Shutdown() {
 	}
 }
 
-func randomRequestDelay() time.Duration {
-	window := requestJitterMaxDelay - requestJitterMinDelay
+// randomRequestDelay 返回非缓存请求前的随机延迟
+func (s *VideoService) randomRequestDelay() time.Duration {
+	window := s.jitterMax - s.jitterMin
 	if window <= 0 {
-		return requestJitterMinDelay
+		return s.jitterMin
 	}
-	return requestJitterMinDelay + time.Duration(rand.Int63n(int64(window)))
+	return s.jitterMin + time.Duration(rand.Int63n(int64(window)))
 }
@@ -0,0 +1,56 @@
+// Package service 视频汇总单元测试
+package service
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/models"
+)
+
+// TestFetchAllVideos_Group 测试按分组汇总来源
+func TestFetchAllVideos_Group(t *testing.T) {
+	cfg := &config.Config{
+		Channels: []config.ChannelInfo{{Mid: "1", Name: "科技"}, {Mid: "2", Name: "游戏"}, {Mid: "3", Name: "数码"}},
+		Groups:   []config.GroupInfo{{Name: "tech", Channels: []string{"科技", "3"}}},
+	}
+	s := NewVideoService(cfg, config.FetchConfig{Workers: 1})
+	defer s.Shutdown()
+
+	now := time.Now()
+	for i, mid := range []string{"1", "2", "3"} {
+		s.setCachedVideos(mid, models.VideoList{{Bvid: "BV" + mid, TimePosted: now.Add(time.Duration(i) * time.Minute)}}, 10)
+	}
+
+	tests := []struct {
+		name     string
+		group    string
+		expected string
+	}{
+		{"全部来源", "", "BV3,BV2,BV1"},
+		{"分组内来源", "tech", "BV3,BV1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			videos, err := s.FetchAllVideos(tt.group, 10, 300)
+			if err != nil {
+				t.Fatalf("FetchAllVideos() error = %v", err)
+			}
+			bvids := make([]string, 0, len(videos))
+			for _, v := range videos {
+				bvids = append(bvids, v.Bvid)
+			}
+			if strings.Join(bvids, ",") != tt.expected {
+				t.Errorf("FetchAllVideos() = %v, want %s", bvids, tt.expected)
+			}
+		})
+	}
+
+	if _, err := s.FetchAllVideos("music", 10, 300); !errors.Is(err, ErrGroupNotFound) {
+		t.Errorf("未配置的分组 error = %v, want ErrGroupNotFound", err)
+	}
+}
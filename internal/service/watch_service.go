@@ -0,0 +1,39 @@
+// Package service 提供已看完视频的过滤
+package service
+
+import (
+	"glance-bilibili/internal/config"
+	"glance-bilibili/internal/logger"
+	"glance-bilibili/internal/models"
+)
+
+// watchedHistoryDepth 判断是否已看完时读取的历史记录条数
+const watchedHistoryDepth = 100
+
+// HideWatched 移除最近观看历史中已看完的视频
+// 返回新的列表，不修改传入的列表；历史记录获取失败时原样返回
+func (s *VideoService) HideWatched(videos models.VideoList, cacheTTLSeconds int) models.VideoList {
+	history, err := s.fetchSingleSource(config.ChannelInfo{Type: config.SourceTypeHistory}, watchedHistoryDepth, cacheTTLSeconds)
+	if err != nil {
+		logger.Warnw("获取观看历史失败，不过滤已看完的视频", "error", err)
+		return videos
+	}
+
+	finished := make(map[string]bool, len(history))
+	for _, v := range history {
+		if v.Finished() {
+			finished[v.Bvid] = true
+		}
+	}
+	if len(finished) == 0 {
+		return videos
+	}
+
+	result := make(models.VideoList, 0, len(videos))
+	for _, v := range videos {
+		if !finished[v.Bvid] {
+			result = append(result, v)
+		}
+	}
+	return result
+}
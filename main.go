@@ -22,24 +22,74 @@ func main() {
 	logger.AutoInit()
 	defer logger.Sync()
 
-	// 解析命令行参数
-	configPath := flag.String("config", "", "配置文件路径")
-	port := flag.Int("port", 8082, "HTTP 服务端口")
-	limit := flag.Int("limit", 25, "默认显示视频数量")
+	// 子命令: 从公开关注列表导入 UP 主
+	if len(os.Args) > 1 && os.Args[1] == "import-follows" {
+		code := runImportFollows(os.Args[2:])
+		logger.Sync()
+		os.Exit(code)
+	}
+
+	// 解析命令行参数，显式指定的参数优先于环境变量与配置文件
+	configPath := flag.String("config", "", "配置文件路径（.json 或 .yaml）")
+	port := flag.Int("port", config.DefaultPort, "HTTP 服务端口")
+	limit := flag.Int("limit", config.DefaultLimit, "默认显示视频数量")
+	adminToken := flag.String("admin-token", "", "管理接口令牌，为空时关闭管理接口（环境变量 ADMIN_TOKEN）")
+	credentialPath := flag.String("credentials", "", "扫码登录凭据文件路径，默认为配置文件同目录的 credentials.json")
+	workers := flag.Int("workers", config.DefaultWorkers, "并发抓取数")
+	cacheTTL := flag.Duration("cache-ttl", config.DefaultCacheTTL, "默认缓存时间，负数为禁用缓存")
+	cookieRefresh := flag.Duration("cookie-refresh", config.DefaultCookieRefresh, "登录 Cookie 刷新检查间隔，负数为禁用")
+	configWatch := flag.Duration("config-watch", config.DefaultConfigWatch, "配置文件变化检查间隔，负数为禁用热重载")
+	profileRefresh := flag.Duration("profile-refresh", config.DefaultProfileRefresh, "频道资料后台刷新间隔，负数为禁用")
 	flag.Parse()
 
-	// 加载配置
+	// 加载配置（短链接与 @名称需联网解析为 mid）
+	config.SetMidResolver(service.NewMidResolver())
 	cfg, err := config.Load(*configPath)
 	if err != nil {
 		logger.Fatalw("加载配置失败", "error", err)
 	}
 
+	settings, err := cfg.Settings()
+	if err != nil {
+		logger.Fatalw("读取全局设置失败", "error", err)
+	}
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "port":
+			settings.Server.Port = *port
+		case "limit":
+			settings.Render.Limit = *limit
+		case "admin-token":
+			settings.Server.AdminToken = *adminToken
+		case "workers":
+			settings.Fetch.Workers = *workers
+		case "config-watch":
+			settings.Server.ConfigWatch = config.Duration(*configWatch)
+		case "cache-ttl":
+			settings.Cache.TTL = config.Duration(*cacheTTL)
+		case "cookie-refresh":
+			settings.Fetch.CookieRefresh = config.Duration(*cookieRefresh)
+		case "profile-refresh":
+			settings.Fetch.ProfileRefresh = config.Duration(*profileRefresh)
+		}
+	})
+	settings.ApplyDefaults()
+	if err := settings.Validate(); err != nil {
+		logger.Fatalw("全局设置不合法", "error", err)
+	}
+
 	logger.Infow("配置加载成功",
 		"up_count", len(cfg.Channels),
 	)
 
 	// 创建服务
-	svc := service.NewVideoService(cfg)
+	svc := service.NewVideoService(cfg, settings.Fetch)
+	if *credentialPath == "" {
+		*credentialPath = config.CredentialsPath(*configPath)
+	}
+	if err := svc.UseCredentialFile(*credentialPath); err != nil {
+		logger.Warnw("加载凭据文件失败", "path", *credentialPath, "error", err)
+	}
 
 	// 初始化（获取 WBI 密钥等）
 	logger.Info("正在初始化...")
@@ -51,23 +101,54 @@ func main() {
 		logger.Info("初始化成功")
 	}
 
+	// 后台定期刷新扫码登录的 Cookie
+	svc.StartCookieRefresh(settings.Fetch.CookieRefresh.Std())
+
+	// 后台定期刷新频道资料（头像、粉丝数等）
+	svc.StartChannelRefresh(settings.Fetch.ProfileRefresh.Std())
+
+	// 监听配置文件，修改后自动重新加载
+	svc.StartConfigWatch(config.ResolvePath(*configPath), settings.Server.ConfigWatch.Std())
+
 	// 创建处理器 (默认展示样式固定为 horizontal-cards)
-	handler, err := api.NewHandler(svc, templatesFS, *limit)
+	handler, err := api.NewHandler(svc, templatesFS, settings)
 	if err != nil {
 		logger.Fatalw("创建处理器失败", "error", err)
 	}
+	handler.EnableAdmin(settings.Server.AdminToken, config.ResolvePath(*configPath))
 
 	// 注册路由
 	http.HandleFunc("/json", handler.JSONHandler)
+	http.HandleFunc("/feed/{group}", handler.VideosHandler)
+	http.HandleFunc("/feed/{group}/json", handler.JSONHandler)
+	http.HandleFunc("/live", handler.LiveHandler)
+	http.HandleFunc("/live/json", handler.LiveJSONHandler)
+	http.HandleFunc("/dynamics", handler.DynamicsHandler)
+	http.HandleFunc("/dynamics/json", handler.DynamicsJSONHandler)
+	http.HandleFunc("/articles", handler.ArticlesHandler)
+	http.HandleFunc("/articles/json", handler.ArticlesJSONHandler)
+	http.HandleFunc("/channels", handler.ChannelsHandler)
+	http.HandleFunc("/channels/json", handler.ChannelsJSONHandler)
+	http.HandleFunc("/admin/import-follows", handler.ImportFollowsHandler)
+	http.HandleFunc("/admin/login", handler.LoginPageHandler)
+	http.HandleFunc("/admin/login/poll", handler.LoginPollHandler)
+	http.HandleFunc("/admin/pool", handler.PoolHandler)
+	http.HandleFunc("/admin/reload", handler.ReloadHandler)
+	http.HandleFunc("/admin/api/channels", handler.ChannelsAPIHandler)
+	http.HandleFunc("/admin/api/channels/reorder", handler.ReorderChannelsHandler)
+	http.HandleFunc("/admin/api/channels/{index}", handler.ChannelAPIHandler)
+	http.HandleFunc("/admin/api/groups", handler.GroupsAPIHandler)
+	http.HandleFunc("/admin/console", handler.ConsoleHandler)
+	http.HandleFunc("/admin/console/{page}", handler.ConsoleHandler)
 	http.HandleFunc("/health", handler.HealthHandler)
 	http.HandleFunc("/help", handler.HelpHandler)
 	http.HandleFunc("/", handler.VideosHandler)
 
 	// 启动服务
-	addr := fmt.Sprintf(":%d", *port)
+	addr := fmt.Sprintf(":%d", settings.Server.Port)
 	logger.Infow("服务启动",
 		"address", fmt.Sprintf("http://localhost%s", addr),
-		"port", *port,
+		"port", settings.Server.Port,
 	)
 
 	if err := http.ListenAndServe(addr, nil); err != nil {